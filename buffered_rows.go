@@ -0,0 +1,139 @@
+package raptor
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+)
+
+// bufferedDB replays rows that were read into memory, so they can be returned
+// as a *sql.Rows that doesn't hold a connection to the database.
+var bufferedDB = sql.OpenDB(bufferedConnector{})
+
+// bufferRows reads every row into memory and closes rows.
+//
+// The writer of a WAL pool has a single connection that stays locked while the
+// rows of a query are open, so the rows of a write such as INSERT ... RETURNING
+// are buffered before the writer is released.
+func bufferRows(ctx context.Context, rows *Rows) (*Rows, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	buffered := &bufferedRows{columns: columns, types: make([]string, len(columnTypes))}
+	for i, t := range columnTypes {
+		buffered.types[i] = t.DatabaseTypeName()
+	}
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		buffered.values = append(buffered.values, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+
+	r, err := bufferedDB.QueryContext(ctx, "", buffered)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rows{Rows: r}, nil
+}
+
+type bufferedRows struct {
+	columns []string
+	types   []string
+	values  [][]any
+}
+
+func (r *bufferedRows) Columns() []string {
+	return r.columns
+}
+
+func (r *bufferedRows) ColumnTypeDatabaseTypeName(i int) string {
+	return r.types[i]
+}
+
+func (r *bufferedRows) Close() error {
+	r.values = nil
+	return nil
+}
+
+func (r *bufferedRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	for i, v := range r.values[0] {
+		dest[i] = v
+	}
+	r.values = r.values[1:]
+	return nil
+}
+
+type bufferedConnector struct{}
+
+func (bufferedConnector) Connect(context.Context) (driver.Conn, error) {
+	return bufferedConn{}, nil
+}
+
+func (bufferedConnector) Driver() driver.Driver {
+	return bufferedDriver{}
+}
+
+type bufferedDriver struct{}
+
+func (bufferedDriver) Open(string) (driver.Conn, error) {
+	return bufferedConn{}, nil
+}
+
+var errBufferedConn = errors.New("raptor: buffered rows can only be queried")
+
+// bufferedConn returns the bufferedRows passed as the only argument of a query.
+type bufferedConn struct{}
+
+func (bufferedConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errBufferedConn
+}
+
+func (bufferedConn) Close() error {
+	return nil
+}
+
+func (bufferedConn) Begin() (driver.Tx, error) {
+	return nil, errBufferedConn
+}
+
+func (bufferedConn) CheckNamedValue(*driver.NamedValue) error {
+	return nil
+}
+
+func (bufferedConn) QueryContext(_ context.Context, _ string, args []driver.NamedValue) (driver.Rows, error) {
+	if len(args) != 1 {
+		return nil, errBufferedConn
+	}
+	rows, ok := args[0].Value.(*bufferedRows)
+	if !ok {
+		return nil, errBufferedConn
+	}
+	return rows, nil
+}
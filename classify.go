@@ -0,0 +1,208 @@
+package raptor
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/maddiesch/go-raptor/statement"
+	"github.com/maddiesch/go-raptor/statement/generator"
+)
+
+// StatementKind describes whether a statement reads from or writes to the database.
+type StatementKind uint8
+
+const (
+	// StatementKindUnknown is the zero value, and means the kind has not been determined.
+	StatementKindUnknown StatementKind = iota
+	// StatementKindRead is a statement that only reads from the database.
+	StatementKindRead
+	// StatementKindWrite is a statement that may modify the database.
+	StatementKindWrite
+)
+
+func (k StatementKind) String() string {
+	switch k {
+	case StatementKindRead:
+		return "read"
+	case StatementKindWrite:
+		return "write"
+	default:
+		return "unknown"
+	}
+}
+
+type statementKindContextKey struct{}
+
+// WithStatementKind returns a context that overrides the classification of any
+// query performed with it.
+//
+// It can be used when ClassifyQuery gets a statement wrong, e.g. a SELECT that
+// calls a user defined function with side effects.
+func WithStatementKind(ctx context.Context, kind StatementKind) context.Context {
+	return context.WithValue(ctx, statementKindContextKey{}, kind)
+}
+
+func statementKindFromContext(ctx context.Context) StatementKind {
+	kind, _ := ctx.Value(statementKindContextKey{}).(StatementKind)
+	return kind
+}
+
+// ClassifyStatement returns the kind of statement the generator produces.
+//
+// The builders from the statement package are classified by type, any other
+// generator is generated and classified using ClassifyQuery.
+func ClassifyStatement(stmt generator.Generator) (StatementKind, error) {
+	switch stmt.(type) {
+	case *statement.SelectBuilder:
+		return StatementKindRead, nil
+	case *statement.InsertBuilder, *statement.InsertReturnBuilder, *statement.ConflictBuilder, *statement.UpdateBuilder, *statement.DeleteBuilder,
+		*statement.CreateTableBuilder, *statement.CreateIndexBuilder, *statement.DropBuilder, *statement.AlterTableBuilder:
+		return StatementKindWrite, nil
+	}
+
+	query, _, err := stmt.Generate()
+	if err != nil {
+		return StatementKindUnknown, err
+	}
+
+	return ClassifyQuery(query), nil
+}
+
+// ClassifyQuery performs a lightweight classification of the SQL query.
+//
+// The query is a read if its leading keyword is SELECT, VALUES or EXPLAIN, or a
+// PRAGMA that doesn't assign a value. A PRAGMA with an argument, such as
+// "PRAGMA journal_mode(WAL)", is a write unless it only inspects the schema,
+// e.g. "PRAGMA table_info(t)". Leading common table expressions are
+// skipped, so the keyword of the statement following the WITH clause is used.
+// A query that contains a RETURNING clause is always a write. Everything else
+// is classified as a write.
+func ClassifyQuery(query string) StatementKind {
+	tokens := tokenizeQuery(query)
+	if len(tokens) == 0 {
+		return StatementKindWrite
+	}
+
+	for _, t := range tokens {
+		if t.word == "RETURNING" {
+			return StatementKindWrite
+		}
+	}
+
+	keyword := tokens[0].word
+	if keyword == "WITH" {
+		keyword = ""
+		for _, t := range tokens[1:] {
+			if t.depth == 0 && isStatementKeyword(t.word) {
+				keyword = t.word
+				break
+			}
+		}
+	}
+
+	switch keyword {
+	case "SELECT", "VALUES", "EXPLAIN":
+		return StatementKindRead
+	case "PRAGMA":
+		var name string
+		for _, t := range tokens[1:] {
+			switch {
+			case t.word == "=":
+				return StatementKindWrite
+			case t.word == "(":
+				if readPragmas[name] {
+					return StatementKindRead
+				}
+				return StatementKindWrite
+			case t.depth == 0:
+				name = t.word
+			}
+		}
+		return StatementKindRead
+	default:
+		return StatementKindWrite
+	}
+}
+
+// readPragmas are the pragmas that take an argument without changing anything.
+var readPragmas = map[string]bool{
+	"TABLE_INFO":        true,
+	"TABLE_XINFO":       true,
+	"TABLE_LIST":        true,
+	"INDEX_INFO":        true,
+	"INDEX_XINFO":       true,
+	"INDEX_LIST":        true,
+	"FOREIGN_KEY_LIST":  true,
+	"FOREIGN_KEY_CHECK": true,
+	"INTEGRITY_CHECK":   true,
+	"QUICK_CHECK":       true,
+}
+
+func isStatementKeyword(w string) bool {
+	switch w {
+	case "SELECT", "VALUES", "INSERT", "REPLACE", "UPDATE", "DELETE":
+		return true
+	default:
+		return false
+	}
+}
+
+type queryToken struct {
+	word  string
+	depth int
+}
+
+// tokenizeQuery splits the query into upper-cased bare words, "=" and "("
+// tokens, along with their parenthesis depth.
+//
+// String literals, quoted identifiers and comments are skipped.
+func tokenizeQuery(query string) []queryToken {
+	var tokens []queryToken
+	var depth int
+
+	runes := []rune(query)
+	skipUntil := func(i int, end string) int {
+		e := []rune(end)
+		for ; i+len(e) <= len(runes); i++ {
+			if string(runes[i:i+len(e)]) == end {
+				return i + len(e)
+			}
+		}
+		return len(runes)
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			i = skipUntil(i+2, "\n")
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i = skipUntil(i+2, "*/")
+		case r == '\'' || r == '"' || r == '`':
+			i = skipUntil(i+1, string(r))
+		case r == '[':
+			i = skipUntil(i+1, "]")
+		case r == '(':
+			tokens = append(tokens, queryToken{"(", depth})
+			depth++
+			i++
+		case r == ')':
+			depth--
+			i++
+		case r == '=':
+			tokens = append(tokens, queryToken{"=", depth})
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '$') {
+				i++
+			}
+			tokens = append(tokens, queryToken{strings.ToUpper(string(runes[start:i])), depth})
+		default:
+			i++
+		}
+	}
+
+	return tokens
+}
@@ -0,0 +1,110 @@
+package raptor_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/maddiesch/go-raptor"
+	"github.com/maddiesch/go-raptor/raptortest"
+	"github.com/maddiesch/go-raptor/statement"
+	"github.com/maddiesch/go-raptor/statement/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected raptor.StatementKind
+	}{
+		{`SELECT * FROM "People";`, raptor.StatementKindRead},
+		{`  select 1`, raptor.StatementKindRead},
+		{`VALUES (1), (2);`, raptor.StatementKindRead},
+		{`EXPLAIN QUERY PLAN SELECT 1;`, raptor.StatementKindRead},
+		{`PRAGMA table_info("People");`, raptor.StatementKindRead},
+		{`PRAGMA journal_mode = WAL;`, raptor.StatementKindWrite},
+		{`PRAGMA journal_mode(WAL);`, raptor.StatementKindWrite},
+		{`PRAGMA foreign_keys(1);`, raptor.StatementKindWrite},
+		{`PRAGMA main.user_version(3);`, raptor.StatementKindWrite},
+		{`PRAGMA user_version;`, raptor.StatementKindRead},
+		{`pragma main.index_list("People");`, raptor.StatementKindRead},
+		{"-- comment\nSELECT 1;", raptor.StatementKindRead},
+		{`/* INSERT */ SELECT 1;`, raptor.StatementKindRead},
+		{`INSERT INTO "People" ("FirstName") VALUES (?);`, raptor.StatementKindWrite},
+		{`INSERT INTO "People" ("FirstName") VALUES (?) RETURNING "ID";`, raptor.StatementKindWrite},
+		{`UPDATE "People" SET "FirstName" = ?;`, raptor.StatementKindWrite},
+		{`DELETE FROM "People";`, raptor.StatementKindWrite},
+		{`REPLACE INTO "People" ("ID") VALUES (1);`, raptor.StatementKindWrite},
+		{`CREATE TABLE "Foo" ("ID" INTEGER);`, raptor.StatementKindWrite},
+		{`SELECT 'RETURNING', "RETURNING" FROM "Foo";`, raptor.StatementKindRead},
+		{`WITH "t" AS (SELECT 1) SELECT * FROM "t";`, raptor.StatementKindRead},
+		{`WITH RECURSIVE "t"("n") AS (SELECT 1 UNION ALL SELECT "n" + 1 FROM "t") SELECT * FROM "t";`, raptor.StatementKindRead},
+		{`WITH "t" AS (SELECT 1) INSERT INTO "Foo" SELECT * FROM "t";`, raptor.StatementKindWrite},
+		{`WITH "t" AS (SELECT 1) DELETE FROM "Foo" WHERE "ID" IN "t";`, raptor.StatementKindWrite},
+		{``, raptor.StatementKindWrite},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			assert.Equal(t, test.expected, raptor.ClassifyQuery(test.query))
+		})
+	}
+}
+
+func TestClassifyStatement(t *testing.T) {
+	tests := []struct {
+		statement generator.Generator
+		expected  raptor.StatementKind
+	}{
+		{statement.Select().From("People"), raptor.StatementKindRead},
+		{statement.Exists(statement.Select().From("People")), raptor.StatementKindRead},
+		{statement.Insert().Into("People"), raptor.StatementKindWrite},
+		{statement.Insert().Into("People").Returning("ID"), raptor.StatementKindWrite},
+		{statement.Update("People").SetValue("FirstName", "Maddie"), raptor.StatementKindWrite},
+		{statement.Delete().From("People"), raptor.StatementKindWrite},
+		{statement.CreateTable("People"), raptor.StatementKindWrite},
+		{statement.DropTable("People"), raptor.StatementKindWrite},
+	}
+
+	for _, test := range tests {
+		kind, err := raptor.ClassifyStatement(test.statement)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, kind)
+	}
+
+	t.Run("given a failing generator", func(t *testing.T) {
+		_, err := raptor.ClassifyStatement(&raptortest.FailureGenerator{})
+		assert.Error(t, err)
+	})
+}
+
+func TestWithStatementKind(t *testing.T) {
+	p, err := raptor.NewWALPool(filepath.Join(t.TempDir(), "classify.db"), 1)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+
+	ctx := context.Background()
+
+	// Readers have query_only enabled, the writer doesn't, so it tells which
+	// connection a query ran on.
+	var queryOnly bool
+	require.NoError(t, p.QueryRow(ctx, `PRAGMA query_only;`).Scan(&queryOnly))
+	assert.True(t, queryOnly)
+
+	require.NoError(t, p.QueryRow(raptor.WithStatementKind(ctx, raptor.StatementKindWrite), `PRAGMA query_only;`).Scan(&queryOnly))
+	assert.False(t, queryOnly)
+	assert.Equal(t, "write", raptor.StatementKindWrite.String())
+
+	t.Run("pragma with an argument runs on the writer", func(t *testing.T) {
+		rows, err := p.Query(ctx, `PRAGMA user_version(3);`)
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+
+		var version int64
+		require.NoError(t, p.QueryRow(ctx, `PRAGMA user_version;`).Scan(&version))
+		assert.Equal(t, int64(3), version)
+	})
+}
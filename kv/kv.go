@@ -18,9 +18,10 @@ const (
 )
 
 func Prepare(ctx context.Context, db raptor.DB) error {
-	stmt := statement.CreateTable(KVTableName).IfNotExists().PrimaryKey(keyName, statement.ColumnTypeText).Column(
+	stmt := statement.CreateTable(KVTableName).IfNotExists().Column(
+		statement.Column(keyName, statement.ColumnTypeText).PrimaryKey(),
 		statement.Column(valueName, statement.ColumnTypeBlob).NotNull(),
-		statement.Column("CreatedAt", statement.ColumnTypeInteger).NotNull().Default(`CURRENT_TIMESTAMP`),
+		statement.Column("CreatedAt", statement.ColumnTypeInteger).NotNull().DefaultValue(statement.DefaultUnixEpoch),
 		statement.Column("UpdatedAt", statement.ColumnTypeInteger).NotNull(),
 	)
 
@@ -34,7 +35,8 @@ func Prepare(ctx context.Context, db raptor.DB) error {
 }
 
 func Set(ctx context.Context, db raptor.Executor, key string, value []byte) error {
-	stmt := statement.Insert().OrReplace().Into(KVTableName).Value(valueName, value).Value(keyName, key).Value("UpdatedAt", time.Now().Unix())
+	stmt := statement.Insert().Into(KVTableName).Value(valueName, value).Value(keyName, key).Value("UpdatedAt", time.Now().Unix()).
+		OnConflict(keyName).DoUpdateSetExcluded(valueName, "UpdatedAt")
 	_, err := raptor.ExecStatement(ctx, db, stmt)
 	return err
 }
@@ -32,9 +32,23 @@ func TestCRUD(t *testing.T) {
 	err = kv.Set(ctx, conn, "test-key", []byte("value"))
 	assert.NoError(t, err)
 
+	// Backdate the row, so overwriting the key can be told apart from replacing the row.
+	_, err = conn.Exec(ctx, `UPDATE "`+kv.KVTableName+`" SET "CreatedAt" = 1 WHERE "Key" = ?;`, "test-key")
+	require.NoError(t, err)
+
 	err = kv.Set(ctx, conn, "test-key", []byte("value2"))
 	assert.NoError(t, err)
 
+	var createdAt int64
+	err = conn.QueryRow(ctx, `SELECT "CreatedAt" FROM "`+kv.KVTableName+`" WHERE "Key" = ?;`, "test-key").Scan(&createdAt)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), createdAt, "Set should update the existing row in place")
+
+	var createdAtType string
+	err = conn.QueryRow(ctx, `SELECT typeof("CreatedAt") FROM "`+kv.KVTableName+`" WHERE "Key" = ?;`, "test-key").Scan(&createdAtType)
+	require.NoError(t, err)
+	assert.Equal(t, "integer", createdAtType)
+
 	val, err := kv.Get(ctx, conn, "test-key")
 	assert.NoError(t, err)
 	assert.Equal(t, []byte("value2"), val)
@@ -6,6 +6,7 @@ import (
 	"github.com/maddiesch/go-raptor"
 	"github.com/maddiesch/go-raptor/statement"
 	"github.com/maddiesch/go-raptor/statement/conditional"
+	"github.com/maddiesch/go-raptor/statement/generator"
 )
 
 const (
@@ -16,6 +17,12 @@ type Migration struct {
 	Name string
 	Up   []string
 	Down []string
+
+	// UpStatements are performed after the queries in Up, e.g. schema statements
+	// created with the statement package builders.
+	UpStatements []generator.Generator
+	// DownStatements are performed after the queries in Down.
+	DownStatements []generator.Generator
 }
 
 func Up(ctx context.Context, db raptor.DB, m ...Migration) error {
@@ -41,6 +48,11 @@ func Up(ctx context.Context, db raptor.DB, m ...Migration) error {
 					return err
 				}
 			}
+			for _, s := range mig.UpStatements {
+				if _, err := raptor.ExecStatement(ctx, d, s); err != nil {
+					return err
+				}
+			}
 
 			_, err := raptor.ExecStatement(ctx, d, statement.Insert().Into(MigrationTableName).Value("name", mig.Name))
 
@@ -79,6 +91,11 @@ func Down(ctx context.Context, db raptor.DB, m ...Migration) error {
 					return err
 				}
 			}
+			for _, s := range mig.DownStatements {
+				if _, err := raptor.ExecStatement(ctx, d, s); err != nil {
+					return err
+				}
+			}
 
 			_, err := raptor.ExecStatement(ctx, d, statement.Delete().From(MigrationTableName).Where(conditional.Equal("name", mig.Name)))
 
@@ -93,7 +110,9 @@ func Down(ctx context.Context, db raptor.DB, m ...Migration) error {
 }
 
 func createMigrationTable(ctx context.Context, db raptor.DB) error {
-	createTableStatement := statement.CreateTable(MigrationTableName).IfNotExists().PrimaryKey("name", statement.ColumnTypeText)
+	createTableStatement := statement.CreateTable(MigrationTableName).IfNotExists().Column(
+		statement.Column("name", statement.ColumnTypeText).PrimaryKey(),
+	)
 	_, err := raptor.ExecStatement(ctx, db, createTableStatement)
 	if err != nil {
 		return err
@@ -7,6 +7,8 @@ import (
 
 	"github.com/maddiesch/go-raptor"
 	"github.com/maddiesch/go-raptor/migrate"
+	"github.com/maddiesch/go-raptor/statement"
+	"github.com/maddiesch/go-raptor/statement/generator"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -87,3 +89,42 @@ func TestDown(t *testing.T) {
 	err = migrate.Down(context.Background(), db, m...)
 	assert.NoError(t, err)
 }
+
+func TestUpStatements(t *testing.T) {
+	db, err := raptor.New(":memory:?mode=memory&cache=shared")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	db.SetLogger(raptor.NewQueryLogger(os.Stderr))
+
+	m := migrate.Migration{
+		Name: "testing-migration-statements",
+		UpStatements: []generator.Generator{
+			statement.CreateTable("migration_table").PrimaryKey("ID", statement.ColumnTypeInteger).Column(
+				statement.Column("Name", statement.ColumnTypeText),
+			),
+			statement.CreateIndex("migration_table_name").On("migration_table", "Name").Unique(),
+			statement.AlterTable("migration_table").AddColumn(statement.Column("Age", statement.ColumnTypeInteger)),
+		},
+		DownStatements: []generator.Generator{
+			statement.DropIndex("migration_table_name"),
+			statement.DropTable("migration_table"),
+		},
+	}
+
+	err = migrate.Up(context.Background(), db, m)
+	require.NoError(t, err)
+
+	_, err = db.Exec(context.Background(), `INSERT INTO "migration_table" ("Name", "Age") VALUES ('Maddie', 30);`)
+	require.NoError(t, err)
+
+	err = migrate.Down(context.Background(), db, m)
+	require.NoError(t, err)
+
+	var count int
+	err = db.QueryRow(context.Background(), `SELECT COUNT(*) FROM "sqlite_schema" WHERE "tbl_name" = 'migration_table';`).Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
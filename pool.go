@@ -2,24 +2,34 @@ package raptor
 
 import (
 	"context"
+	"errors"
 	"sync"
 
 	"github.com/maddiesch/go-raptor/pool"
+	"github.com/maddiesch/go-raptor/statement/generator"
 )
 
 // Pool implements a thread-safe pool of database connections.
 //
 // It works as a single-writer multi-reader connection.
 //
-// Warning: The pool is not safe to perform mutating queries:
+// Query and QueryRow classify the query using ClassifyQuery, and perform
+// mutating queries such as
 // pool.Query(ctx, "INSERT INTO table (id) VALUES (?) RETURNING id", ...)
-// will not lock the pool for writing even though it's a mutating query.
+// through the writer path. The classification can be overridden by passing a
+// context created with WithStatementKind. The rows of a query on the writer
+// path are read into memory before it returns, so they don't keep the writer
+// from performing other queries.
 //
-// If you plan on using mutating queries, you should use ForWriting, or Transact
+// Pools created with NewWALPool use a dedicated writer connection, and allow
+// reads to continue while a write is in progress. Each of their readers is a
+// single SQLite connection, so a reader used by Query or QueryRow is only
+// returned to the pool once its rows are closed, or its row is scanned.
 type Pool struct {
 	pool.Pool[*Conn]
 
-	wLock sync.RWMutex
+	writer pool.Pool[*Conn] // Dedicated writer connection, nil unless created with NewWALPool
+	wLock  sync.RWMutex
 }
 
 // Create a new pool with the given number of maximum connections.
@@ -41,8 +51,28 @@ func NewPool(size int64, fn func(context.Context) (*Conn, error)) *Pool {
 	}
 }
 
+// writePool returns the pool that mutating queries are checked out from.
+func (p *Pool) writePool() pool.Pool[*Conn] {
+	if p.writer != nil {
+		return p.writer
+	}
+	return p.Pool
+}
+
+// readLock takes the read side of the write lock and returns the matching
+// unlock function.
+//
+// WAL pools allow readers to run alongside the writer, so no lock is taken.
+func (p *Pool) readLock() func() {
+	if p.writer != nil {
+		return func() {}
+	}
+	p.wLock.RLock()
+	return p.wLock.RUnlock
+}
+
 func (p *Pool) Exec(ctx context.Context, query string, args ...any) (Result, error) {
-	return pool.WithValue(ctx, p.Pool, func(conn *Conn) (Result, error) {
+	return pool.WithValue(ctx, p.writePool(), func(conn *Conn) (Result, error) {
 		p.wLock.Lock()
 		defer p.wLock.Unlock()
 
@@ -51,29 +81,117 @@ func (p *Pool) Exec(ctx context.Context, query string, args ...any) (Result, err
 }
 
 func (p *Pool) Query(ctx context.Context, query string, args ...any) (*Rows, error) {
+	if p.kind(ctx, query) == StatementKindWrite {
+		return pool.WithValue(ctx, p.writePool(), func(conn *Conn) (*Rows, error) {
+			p.wLock.Lock()
+			defer p.wLock.Unlock()
+
+			rows, err := conn.Query(ctx, query, args...)
+			if err != nil {
+				return nil, err
+			}
+			return bufferRows(ctx, rows)
+		})
+	}
+
+	if p.writer != nil {
+		conn, err := p.Pool.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := conn.Query(ctx, query, args...)
+		if err != nil {
+			_ = p.Pool.Put(conn)
+			return nil, err
+		}
+		rows.release = func() { _ = p.Pool.Put(conn) }
+		return rows, nil
+	}
+
 	return pool.WithValue(ctx, p.Pool, func(conn *Conn) (*Rows, error) {
-		p.wLock.RLock()
-		defer p.wLock.RUnlock()
+		defer p.readLock()()
 
 		return conn.Query(ctx, query, args...)
 	})
 }
 
 func (p *Pool) QueryRow(ctx context.Context, query string, args ...any) Row {
-	row, err := pool.WithValue(ctx, p.Pool, func(conn *Conn) (Row, error) {
-		p.wLock.RLock()
-		defer p.wLock.RUnlock()
+	var row Row
+	var err error
 
-		return conn.QueryRow(ctx, query, args...), nil
-	})
+	if p.kind(ctx, query) == StatementKindWrite {
+		row, err = pool.WithValue(ctx, p.writePool(), func(conn *Conn) (Row, error) {
+			p.wLock.Lock()
+			defer p.wLock.Unlock()
+
+			return conn.QueryRow(ctx, query, args...), nil
+		})
+	} else if p.writer != nil {
+		var conn *Conn
+		if conn, err = p.Pool.Get(ctx); err == nil {
+			row = conn.QueryRow(ctx, query, args...)
+			if row.Err() != nil {
+				_ = p.Pool.Put(conn)
+			} else {
+				row.(*connRow).release = func() { _ = p.Pool.Put(conn) }
+			}
+		}
+	} else {
+		row, err = pool.WithValue(ctx, p.Pool, func(conn *Conn) (Row, error) {
+			defer p.readLock()()
+
+			return conn.QueryRow(ctx, query, args...), nil
+		})
+	}
 	if err != nil {
 		return &poolRowErr{err}
 	}
 	return row
 }
 
+// QueryStatement performs the statement, routing it by the kind reported by ClassifyStatement.
+func (p *Pool) QueryStatement(ctx context.Context, stmt generator.Generator) (*Rows, error) {
+	ctx, err := p.statementContext(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	return QueryStatement(ctx, p, stmt)
+}
+
+// QueryRowStatement performs the statement, routing it by the kind reported by ClassifyStatement.
+func (p *Pool) QueryRowStatement(ctx context.Context, stmt generator.Generator) Row {
+	ctx, err := p.statementContext(ctx, stmt)
+	if err != nil {
+		return &poolRowErr{err}
+	}
+
+	return QueryRowStatement(ctx, p, stmt)
+}
+
+// kind returns the kind of the query, preferring an override from the context.
+func (p *Pool) kind(ctx context.Context, query string) StatementKind {
+	if kind := statementKindFromContext(ctx); kind != StatementKindUnknown {
+		return kind
+	}
+	return ClassifyQuery(query)
+}
+
+func (p *Pool) statementContext(ctx context.Context, stmt generator.Generator) (context.Context, error) {
+	if statementKindFromContext(ctx) != StatementKindUnknown {
+		return ctx, nil
+	}
+
+	kind, err := ClassifyStatement(stmt)
+	if err != nil {
+		return ctx, err
+	}
+
+	return WithStatementKind(ctx, kind), nil
+}
+
 func (p *Pool) Transact(ctx context.Context, fn func(DB) error) error {
-	return pool.With(ctx, p.Pool, func(conn *Conn) error {
+	return pool.With(ctx, p.writePool(), func(conn *Conn) error {
 		p.wLock.Lock()
 		defer p.wLock.Unlock()
 
@@ -81,9 +199,21 @@ func (p *Pool) Transact(ctx context.Context, fn func(DB) error) error {
 	})
 }
 
+// ReadTransact performs fn inside a read-only transaction on a reader connection.
+//
+// Every query in fn sees the same snapshot of the database. Writes are rejected
+// with ErrReadOnlyTransaction, and the transaction is always rolled back.
+func (p *Pool) ReadTransact(ctx context.Context, fn func(DB) error) error {
+	return pool.With(ctx, p.Pool, func(conn *Conn) error {
+		defer p.readLock()()
+
+		return conn.readTransact(ctx, fn)
+	})
+}
+
 // ForWriting is a helper function to checkout a DB connection for mutating queries.
 func (p *Pool) ForWriting(ctx context.Context, fn func(DB) error) error {
-	return pool.With(ctx, p.Pool, func(conn *Conn) error {
+	return pool.With(ctx, p.writePool(), func(conn *Conn) error {
 		p.wLock.Lock()
 		defer p.wLock.Unlock()
 
@@ -94,24 +224,52 @@ func (p *Pool) ForWriting(ctx context.Context, fn func(DB) error) error {
 // Reader is a helper function to checkout a DB connection for read-only queries.
 // It returns a DB interface, and a function that must be called to return the
 // connection to the pool.
+//
+// A reader of a WAL pool is a single SQLite connection, so the rows of a query
+// must be closed before it can perform another query.
 func (p *Pool) Reader(ctx context.Context) (DB, func() error, error) {
 	conn, err := p.Pool.Get(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	p.wLock.RLock()
+	unlock := p.readLock()
 
 	close := func() error {
-		p.wLock.RUnlock()
+		unlock()
 		return p.Pool.Put(conn)
 	}
 
 	return conn, close, nil
 }
 
+// Resize changes the maximum number of connections in the pool.
+//
+// For pools created with NewWALPool this is the number of reader connections,
+// there is always a single writer.
+func (p *Pool) Resize(ctx context.Context, size int64) error {
+	return p.Pool.Resize(ctx, size)
+}
+
+// Close closes the pool, including the dedicated writer connection of a WAL pool.
+//
+// New queries are rejected with ErrPoolClosed, and Close waits for checked out
+// connections to be returned before closing them. If the context expires first,
+// the returned error contains a *pool.LeakError.
+func (p *Pool) Close(ctx context.Context) error {
+	err := p.Pool.Close(ctx)
+	if p.writer != nil {
+		err = errors.Join(err, p.writer.Close(ctx))
+	}
+	return err
+}
+
 var _ DB = (*Pool)(nil)
 
+var (
+	ErrPoolClosed = pool.ErrPoolClosed
+)
+
 type poolRowErr struct {
 	err error
 }
@@ -2,10 +2,10 @@ package pool
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
 	"sync"
-
-	"golang.org/x/sync/semaphore"
 )
 
 type Pool[T any] interface {
@@ -15,6 +15,8 @@ type Pool[T any] interface {
 
 	Close(context.Context) error
 
+	Resize(context.Context, int64) error
+
 	Len() int
 }
 
@@ -23,87 +25,269 @@ type Config struct {
 	Preload int64
 }
 
+var (
+	// ErrPoolClosed is returned when getting a value from a pool that has been closed.
+	ErrPoolClosed = errors.New("pool: closed")
+
+	// ErrInvalidSize is returned when resizing a pool to less than 1 value.
+	ErrInvalidSize = errors.New("pool: size must be at least 1")
+)
+
 func New[T any](c Config, fn func(context.Context) (T, error)) Pool[T] {
 	return &pool[T]{
-		max:       c.MaxSize,
-		builder:   fn,
-		semaphore: semaphore.NewWeighted(c.MaxSize),
-		values:    make([]T, 0, c.MaxSize),
+		max:     c.MaxSize,
+		builder: fn,
+		values:  make([]T, 0, c.MaxSize),
 	}
 }
 
 type pool[T any] struct {
 	max       int64
 	builder   func(context.Context) (T, error)
-	semaphore *semaphore.Weighted
 	mu        sync.Mutex
 	values    []T
+	inUse     []T             // Values that are checked out of the pool
+	waiters   []chan struct{} // Calls to Get waiting for a value to be available, oldest first
+	closed    bool            // Set once Close is called, new values are rejected
+	drained   chan struct{}   // Closed when the last checked out value is returned to a closed pool
+	closeErrs []error         // Errors from closing values returned after the pool was closed
 }
 
 func (p *pool[T]) Get(ctx context.Context) (T, error) {
-	if err := p.semaphore.Acquire(ctx, 1); err != nil {
-		var v T
-		return v, err
-	}
+	var v T
 
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	for {
+		if p.closed {
+			return v, ErrPoolClosed
+		}
+		if int64(len(p.inUse)) < p.max {
+			break
+		}
+		if err := p.wait(ctx); err != nil {
+			return v, err
+		}
+	}
+
 	if len(p.values) == 0 {
-		if v, err := p.builder(ctx); err != nil {
-			p.semaphore.Release(1)
+		v, err := p.builder(ctx)
+		if err != nil {
+			p.notify(1)
 			return v, err
-		} else {
-			return v, nil
 		}
+		p.inUse = append(p.inUse, v)
+		return v, nil
 	}
 
-	v := p.values[len(p.values)-1]
+	v = p.values[len(p.values)-1]
 	p.values = p.values[:len(p.values)-1]
+	p.inUse = append(p.inUse, v)
 
 	return v, nil
 }
 
+// wait releases the lock until the waiter is notified or the context is done.
+//
+// Must be called with the lock held.
+func (p *pool[T]) wait(ctx context.Context) error {
+	ch := make(chan struct{})
+	p.waiters = append(p.waiters, ch)
+
+	p.mu.Unlock()
+	select {
+	case <-ch:
+		p.mu.Lock()
+		return nil
+	case <-ctx.Done():
+		p.mu.Lock()
+	}
+
+	for i, w := range p.waiters {
+		if w == ch {
+			p.waiters = append(p.waiters[:i], p.waiters[i+1:]...)
+			return ctx.Err()
+		}
+	}
+
+	// The waiter was notified at the same time the context was canceled, pass
+	// the notification on to the next waiter.
+	p.notify(1)
+
+	return ctx.Err()
+}
+
+// notify wakes up to n of the oldest waiters.
+//
+// Must be called with the lock held.
+func (p *pool[T]) notify(n int64) {
+	for ; n > 0 && len(p.waiters) > 0; n-- {
+		close(p.waiters[0])
+		p.waiters = p.waiters[1:]
+	}
+}
+
+// Put returns the value to the pool.
+//
+// If the pool has been closed, or shrunk below the number of values it holds,
+// the value is closed instead.
 func (p *pool[T]) Put(v T) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	p.semaphore.Release(1)
+	if len(p.inUse) == 0 {
+		panic("pool: Put called without a matching Get")
+	}
+
+	p.checkin(v)
+
+	if p.closed {
+		if err := closeValue(context.Background(), v); err != nil {
+			p.closeErrs = append(p.closeErrs, err)
+		}
+		if len(p.inUse) == 0 && p.drained != nil {
+			close(p.drained)
+			p.drained = nil
+		}
+		return nil
+	}
+
+	p.notify(1)
+
+	if int64(len(p.values)+len(p.inUse)) >= p.max {
+		return closeValue(context.Background(), v)
+	}
 
 	p.values = append(p.values, v)
 
 	return nil
 }
 
-func (p *pool[T]) Close(ctx context.Context) error {
-	if err := p.semaphore.Acquire(ctx, p.max); err != nil {
-		return err
+// Resize changes the maximum number of values the pool holds.
+//
+// Growing the pool takes effect immediately. Shrinking the pool closes idle
+// values, and values that are checked out are closed when they are returned.
+func (p *pool[T]) Resize(ctx context.Context, size int64) error {
+	if size < 1 {
+		return ErrInvalidSize
 	}
-	defer p.semaphore.Release(p.max)
 
 	p.mu.Lock()
-	defer p.mu.Unlock()
+
+	if p.closed {
+		p.mu.Unlock()
+		return ErrPoolClosed
+	}
+
+	if size > p.max {
+		p.notify(size - p.max)
+	}
+	p.max = size
+
+	var retired []T
+	if excess := int64(len(p.values)+len(p.inUse)) - size; excess > 0 {
+		n := min(excess, int64(len(p.values)))
+		retired = p.values[:n]
+		p.values = append([]T(nil), p.values[n:]...)
+	}
+
+	p.mu.Unlock()
 
 	var errList []error
 
-	for _, v := range p.values {
-		switch v := any(v).(type) {
-		case CloseContextErr:
-			if err := v.Close(ctx); err != nil {
-				errList = append(errList, err)
-			}
-		case CloseContext:
-			v.Close(ctx)
-		case CloseErr:
-			if err := v.Close(); err != nil {
-				errList = append(errList, err)
+	for _, v := range retired {
+		if err := closeValue(ctx, v); err != nil {
+			errList = append(errList, err)
+		}
+	}
+
+	switch len(errList) {
+	case 0:
+		return nil
+	case 1:
+		return errList[0]
+	default:
+		return &CloseError{Children: errList}
+	}
+}
+
+// checkin removes the value from the list of checked out values.
+//
+// Values are matched using ==, if the value can't be matched the oldest
+// checked out value is removed instead.
+func (p *pool[T]) checkin(v T) {
+	if len(p.inUse) == 0 {
+		return
+	}
+
+	index := 0
+	for i, u := range p.inUse {
+		if equal(u, v) {
+			index = i
+			break
+		}
+	}
+
+	p.inUse = append(p.inUse[:index], p.inUse[index+1:]...)
+}
+
+func equal[T any](a, b T) bool {
+	av, bv := any(a), any(b)
+	if t := reflect.TypeOf(av); t != nil && !t.Comparable() {
+		return false
+	}
+	return av == bv
+}
+
+// Close closes the pool.
+//
+// New calls to Get are rejected with ErrPoolClosed, idle values are closed
+// immediately and Close waits for checked out values to be returned and closed.
+// If the context expires first, a *LeakError listing the values that were not
+// returned is reported.
+func (p *pool[T]) Close(ctx context.Context) error {
+	p.mu.Lock()
+	p.closed = true
+	idle := p.values
+	p.values = make([]T, 0)
+	p.notify(int64(len(p.waiters)))
+	var drained chan struct{}
+	if len(p.inUse) > 0 {
+		if p.drained == nil {
+			p.drained = make(chan struct{})
+		}
+		drained = p.drained
+	}
+	p.mu.Unlock()
+
+	var errList []error
+
+	for _, v := range idle {
+		if err := closeValue(ctx, v); err != nil {
+			errList = append(errList, err)
+		}
+	}
+
+	if drained != nil {
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			p.mu.Lock()
+			leaked := make([]any, len(p.inUse))
+			for i, v := range p.inUse {
+				leaked[i] = v
 			}
-		case Closer:
-			v.Close()
+			p.mu.Unlock()
+
+			errList = append(errList, &LeakError{Leaked: leaked, Err: ctx.Err()})
 		}
 	}
 
-	p.values = make([]T, 0, p.max)
+	p.mu.Lock()
+	errList = append(errList, p.closeErrs...)
+	p.closeErrs = nil
+	p.mu.Unlock()
 
 	switch len(errList) {
 	case 0:
@@ -115,6 +299,20 @@ func (p *pool[T]) Close(ctx context.Context) error {
 	}
 }
 
+func closeValue(ctx context.Context, v any) error {
+	switch v := v.(type) {
+	case CloseContextErr:
+		return v.Close(ctx)
+	case CloseContext:
+		v.Close(ctx)
+	case CloseErr:
+		return v.Close()
+	case Closer:
+		v.Close()
+	}
+	return nil
+}
+
 func (p *pool[T]) Len() int {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -190,3 +388,22 @@ type CloseError struct {
 func (e *CloseError) Error() string {
 	return fmt.Sprintf("pool closed with %d errors", len(e.Children))
 }
+
+func (e *CloseError) Unwrap() []error {
+	return e.Children
+}
+
+// LeakError is returned by Close when the context expires before every checked
+// out value was returned to the pool.
+type LeakError struct {
+	Leaked []any
+	Err    error
+}
+
+func (e *LeakError) Error() string {
+	return fmt.Sprintf("pool closed with %d leaked values: %s", len(e.Leaked), e.Err)
+}
+
+func (e *LeakError) Unwrap() error {
+	return e.Err
+}
@@ -192,6 +192,54 @@ func TestPool(t *testing.T) {
 		assert.ErrorIs(t, p.Close(ctx), context.DeadlineExceeded)
 	})
 
+	t.Run("Close rejects new values", func(t *testing.T) {
+		p := pool.New(pool.Config{MaxSize: 1}, func(_ context.Context) (int64, error) {
+			return 0, nil
+		})
+
+		assert.NoError(t, p.Close(context.Background()))
+
+		_, err := p.Get(context.Background())
+		assert.ErrorIs(t, err, pool.ErrPoolClosed)
+	})
+
+	t.Run("Close drains checked out values", func(t *testing.T) {
+		c := &poolValueCloser{}
+
+		p := pool.New[any](pool.Config{MaxSize: 1}, func(ctx context.Context) (any, error) {
+			return c, nil
+		})
+
+		v, err := p.Get(context.Background())
+		assert.NoError(t, err)
+
+		go func() {
+			time.Sleep(5 * time.Millisecond)
+			p.Put(v)
+		}()
+
+		assert.NoError(t, p.Close(context.Background()))
+		assert.True(t, c.called.Load())
+	})
+
+	t.Run("Close reports leaked values", func(t *testing.T) {
+		p := pool.New(pool.Config{MaxSize: 2}, func(_ context.Context) (int64, error) {
+			return 42, nil
+		})
+
+		p.Get(context.Background())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		t.Cleanup(cancel)
+
+		err := p.Close(ctx)
+
+		var leakErr *pool.LeakError
+		if assert.ErrorAs(t, err, &leakErr) {
+			assert.Equal(t, []any{int64(42)}, leakErr.Leaked)
+		}
+	})
+
 	t.Run("Close with multiple errors", func(t *testing.T) {
 		c := &poolValueCloserContextErr{err: errors.New(t.Name())}
 
@@ -249,6 +297,121 @@ func TestPool(t *testing.T) {
 	})
 }
 
+func TestResize(t *testing.T) {
+	t.Run("given an invalid size", func(t *testing.T) {
+		p := pool.New(pool.Config{MaxSize: 1}, func(_ context.Context) (int64, error) {
+			return 0, nil
+		})
+
+		assert.ErrorIs(t, p.Resize(context.Background(), 0), pool.ErrInvalidSize)
+	})
+
+	t.Run("when the pool is closed", func(t *testing.T) {
+		p := pool.New(pool.Config{MaxSize: 1}, func(_ context.Context) (int64, error) {
+			return 0, nil
+		})
+		p.Close(context.Background())
+
+		assert.ErrorIs(t, p.Resize(context.Background(), 2), pool.ErrPoolClosed)
+	})
+
+	t.Run("grow", func(t *testing.T) {
+		var value atomic.Int64
+
+		p := pool.New(pool.Config{MaxSize: 1}, func(_ context.Context) (int64, error) {
+			return value.Add(1), nil
+		})
+		t.Cleanup(func() {
+			p.Close(context.Background())
+		})
+
+		v1, err := p.Get(context.Background())
+		assert.NoError(t, err)
+
+		got := make(chan int64)
+		go func() {
+			v, _ := p.Get(context.Background())
+			got <- v
+		}()
+
+		time.Sleep(5 * time.Millisecond)
+		assert.NoError(t, p.Resize(context.Background(), 2))
+
+		v2 := <-got
+		assert.NotEqual(t, v1, v2)
+
+		p.Put(v1)
+		p.Put(v2)
+		assert.Equal(t, 2, p.Len())
+	})
+
+	t.Run("shrink with checked out values", func(t *testing.T) {
+		c1, c2 := &poolValueCloser{}, &poolValueCloser{}
+		var index atomic.Int64
+
+		p := pool.New[any](pool.Config{MaxSize: 2}, func(ctx context.Context) (any, error) {
+			if index.Add(1) == 1 {
+				return c1, nil
+			}
+			return c2, nil
+		})
+		t.Cleanup(func() {
+			p.Close(context.Background())
+		})
+
+		v1, _ := p.Get(context.Background())
+		v2, _ := p.Get(context.Background())
+
+		assert.NoError(t, p.Resize(context.Background(), 1))
+
+		p.Put(v1)
+		assert.True(t, c1.called.Load())
+		assert.Equal(t, 0, p.Len())
+
+		p.Put(v2)
+		assert.False(t, c2.called.Load())
+		assert.Equal(t, 1, p.Len())
+	})
+
+	t.Run("shrink", func(t *testing.T) {
+		closers := []*poolValueCloser{{}, {}, {}}
+		var index atomic.Int64
+
+		p := pool.New[any](pool.Config{MaxSize: 3}, func(ctx context.Context) (any, error) {
+			return closers[index.Add(1)-1], nil
+		})
+		t.Cleanup(func() {
+			p.Close(context.Background())
+		})
+
+		pool.Load(context.Background(), p, 2)
+
+		v, err := p.Get(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, closers[0], v)
+
+		assert.NoError(t, p.Resize(context.Background(), 1))
+		assert.Equal(t, 0, p.Len())
+		assert.True(t, closers[1].called.Load())
+
+		p.Put(v)
+		assert.Equal(t, 1, p.Len())
+		assert.False(t, closers[0].called.Load())
+
+		v, err = p.Get(context.Background())
+		assert.NoError(t, err)
+		t.Cleanup(func() {
+			p.Put(v)
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		t.Cleanup(cancel)
+
+		_, err = p.Get(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
 func TestLoad(t *testing.T) {
 	var value atomic.Int64
 
@@ -2,12 +2,15 @@ package raptor_test
 
 import (
 	"context"
+	"database/sql"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/maddiesch/go-raptor"
+	"github.com/maddiesch/go-raptor/statement"
+	"github.com/maddiesch/go-raptor/statement/conditional"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
@@ -130,6 +133,47 @@ func TestPool(t *testing.T) {
 	})
 }
 
+func TestPoolClose(t *testing.T) {
+	p := raptor.NewPool(1, func(context.Context) (*raptor.Conn, error) {
+		return raptor.New("file:pool_close.db?cache=shared&mode=memory")
+	})
+
+	_, done, err := p.Reader(context.Background())
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		done()
+	}()
+
+	require.NoError(t, p.Close(context.Background()))
+
+	_, err = p.Exec(context.Background(), `SELECT 1;`)
+	assert.ErrorIs(t, err, raptor.ErrPoolClosed)
+}
+
+func TestPoolResize(t *testing.T) {
+	p := raptor.NewPool(1, func(context.Context) (*raptor.Conn, error) {
+		return raptor.New("file:pool_resize.db?cache=shared&mode=memory")
+	})
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+
+	_, done, err := p.Reader(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() { done() })
+
+	require.NoError(t, p.Resize(context.Background(), 2))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	t.Cleanup(cancel)
+
+	_, done2, err := p.Reader(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { done2() })
+}
+
 func TestPoolReader(t *testing.T) {
 	p := raptor.NewPool(1, func(context.Context) (*raptor.Conn, error) {
 		return raptor.New("file:testing.db?cache=shared&mode=memory")
@@ -147,3 +191,250 @@ func TestPoolReader(t *testing.T) {
 	_, _, err = p.Reader(ctx)
 	require.Error(t, err)
 }
+
+func TestNewWALPool(t *testing.T) {
+	t.Run("given 0 readers", func(t *testing.T) {
+		assert.Panics(t, func() {
+			raptor.NewWALPool(filepath.Join(t.TempDir(), "testing.db"), 0)
+		})
+	})
+
+	p, err := raptor.NewWALPool(filepath.Join(t.TempDir(), "testing.db"), 2, raptor.WithBusyTimeout(time.Second))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+
+	ctx := context.Background()
+
+	var mode string
+	require.NoError(t, p.QueryRow(ctx, `PRAGMA journal_mode;`).Scan(&mode))
+	assert.Equal(t, "wal", mode)
+
+	_, err = p.Exec(ctx, `CREATE TABLE "TestTable" ("ID" INTEGER NOT NULL PRIMARY KEY, "Index" INTEGER);`)
+	require.NoError(t, err)
+
+	t.Run("readers reject writes", func(t *testing.T) {
+		var id int64
+		err := p.QueryRow(raptor.WithStatementKind(ctx, raptor.StatementKindRead), `INSERT INTO "TestTable" ("Index") VALUES (?) RETURNING "ID";`, 1).Scan(&id)
+		assert.Error(t, err)
+	})
+
+	t.Run("mutating queries are routed to the writer", func(t *testing.T) {
+		var id int64
+		err := p.QueryRow(ctx, `INSERT INTO "TestTable" ("Index") VALUES (?) RETURNING "ID";`, 1).Scan(&id)
+		require.NoError(t, err)
+
+		rows, err := p.QueryStatement(ctx, statement.Delete().From("TestTable").Where(conditional.Equal("ID", id)))
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+
+		err = p.QueryRowStatement(ctx, statement.Select("ID").From("TestTable").Where(conditional.Equal("ID", id))).Scan(&id)
+		assert.ErrorIs(t, err, raptor.ErrNoRows)
+	})
+
+	t.Run("reads run alongside the writer", func(t *testing.T) {
+		reader, done, err := p.Reader(ctx)
+		require.NoError(t, err)
+		t.Cleanup(func() { done() })
+
+		err = p.Transact(ctx, func(tx raptor.DB) error {
+			_, err := tx.Exec(ctx, `INSERT INTO "TestTable" ("Index") VALUES (?);`, 1)
+			if err != nil {
+				return err
+			}
+
+			var count int64
+			if err := reader.QueryRow(ctx, `SELECT COUNT(*) FROM "TestTable";`).Scan(&count); err != nil {
+				return err
+			}
+			assert.Equal(t, int64(0), count)
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		var count int64
+		require.NoError(t, reader.QueryRow(ctx, `SELECT COUNT(*) FROM "TestTable";`).Scan(&count))
+		assert.Equal(t, int64(1), count)
+	})
+}
+
+func TestNewWALPool_OpenRows(t *testing.T) {
+	p, err := raptor.NewWALPool(filepath.Join(t.TempDir(), "testing.db"), 2)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := p.Query(ctx, `SELECT 1 UNION ALL SELECT 2;`)
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	var v int64
+	require.NoError(t, p.QueryRow(ctx, `SELECT 3;`).Scan(&v))
+	assert.Equal(t, int64(3), v)
+
+	require.NoError(t, rows.Scan(&v))
+	assert.Equal(t, int64(1), v)
+}
+
+func TestNewWALPool_ReaderLimit(t *testing.T) {
+	p, err := raptor.NewWALPool(filepath.Join(t.TempDir(), "testing.db"), 1)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rows, err := p.Query(ctx, `SELECT 1 UNION ALL SELECT 2;`)
+	require.NoError(t, err)
+	require.True(t, rows.Next())
+
+	t.Run("waits for open rows", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		var v int64
+		assert.ErrorIs(t, p.QueryRow(ctx, `SELECT 3;`).Scan(&v), context.DeadlineExceeded)
+	})
+
+	require.NoError(t, rows.Close())
+
+	t.Run("reader is returned when the rows are closed", func(t *testing.T) {
+		var v int64
+		require.NoError(t, p.QueryRow(ctx, `SELECT 3;`).Scan(&v))
+		assert.Equal(t, int64(3), v)
+	})
+
+	t.Run("reader is returned when the rows are exhausted", func(t *testing.T) {
+		rows, err := p.Query(ctx, `SELECT 1;`)
+		require.NoError(t, err)
+		defer rows.Close()
+		for rows.Next() {
+		}
+
+		var v int64
+		require.NoError(t, p.QueryRow(ctx, `SELECT 3;`).Scan(&v))
+		assert.Equal(t, int64(3), v)
+	})
+}
+
+func TestNewWALPool_OpenReturningRows(t *testing.T) {
+	p, err := raptor.NewWALPool(filepath.Join(t.TempDir(), "testing.db"), 1)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = p.Exec(ctx, `CREATE TABLE "TestTable" ("ID" INTEGER NOT NULL PRIMARY KEY, "Name" TEXT, "Data" BLOB);`)
+	require.NoError(t, err)
+
+	rows, err := p.Query(ctx, `INSERT INTO "TestTable" ("Name", "Data") VALUES ('a', X'CAFE'), ('b', NULL) RETURNING "ID", "Name", "Data";`)
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+
+	_, err = p.Exec(ctx, `INSERT INTO "TestTable" ("Name") VALUES ('c');`)
+	require.NoError(t, err, "the writer should be available while the rows are open")
+
+	columns, err := rows.Columns()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ID", "Name", "Data"}, columns)
+
+	var id int64
+	var name string
+	var data []byte
+	require.NoError(t, rows.Scan(&id, &name, &data))
+	assert.Equal(t, int64(1), id)
+	assert.Equal(t, "a", name)
+	assert.Equal(t, []byte{0xCA, 0xFE}, data)
+
+	require.True(t, rows.Next())
+	var nullable sql.NullString
+	require.NoError(t, rows.Scan(&id, &name, &nullable))
+	assert.Equal(t, int64(2), id)
+	assert.False(t, nullable.Valid)
+
+	assert.False(t, rows.Next())
+	require.NoError(t, rows.Err())
+}
+
+func TestPoolReadTransact(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("WAL pool", func(t *testing.T) {
+		p, err := raptor.NewWALPool(filepath.Join(t.TempDir(), "testing.db"), 1)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, p.Close(context.Background()))
+		})
+
+		_, err = p.Exec(ctx, `CREATE TABLE "TestTable" ("ID" INTEGER NOT NULL PRIMARY KEY, "Index" INTEGER);`)
+		require.NoError(t, err)
+
+		err = p.ReadTransact(ctx, func(tx raptor.DB) error {
+			var before int64
+			if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM "TestTable";`).Scan(&before); err != nil {
+				return err
+			}
+
+			_, err := p.Exec(ctx, `INSERT INTO "TestTable" ("Index") VALUES (?);`, 1)
+			require.NoError(t, err)
+
+			var after int64
+			if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM "TestTable";`).Scan(&after); err != nil {
+				return err
+			}
+			assert.Equal(t, before, after)
+
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	p := raptor.NewPool(1, func(context.Context) (*raptor.Conn, error) {
+		return raptor.New("file:read_transact.db?cache=shared&mode=memory")
+	})
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+
+	_, err := p.Exec(ctx, `CREATE TABLE "TestTable" ("ID" INTEGER NOT NULL PRIMARY KEY, "Index" INTEGER);`)
+	require.NoError(t, err)
+
+	t.Run("rejects writes", func(t *testing.T) {
+		err := p.ReadTransact(ctx, func(tx raptor.DB) error {
+			_, err := tx.Exec(ctx, `INSERT INTO "TestTable" ("Index") VALUES (?);`, 1)
+			return err
+		})
+		assert.ErrorIs(t, err, raptor.ErrReadOnlyTransaction)
+
+		err = p.ReadTransact(ctx, func(tx raptor.DB) error {
+			_, err := tx.Exec(raptor.WithStatementKind(ctx, raptor.StatementKindRead), `INSERT INTO "TestTable" ("Index") VALUES (?);`, 1)
+			return err
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("restores the connection", func(t *testing.T) {
+		err := p.ReadTransact(ctx, func(tx raptor.DB) error {
+			return tx.Transact(ctx, func(tx raptor.DB) error {
+				return raptor.ErrTxRollback
+			})
+		})
+		require.NoError(t, err)
+
+		_, err = p.Exec(ctx, `INSERT INTO "TestTable" ("Index") VALUES (?);`, 1)
+		assert.NoError(t, err)
+	})
+}
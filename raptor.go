@@ -112,6 +112,31 @@ type Result interface {
 // Rows is the result of a query. See sql.Rows for more information.
 type Rows struct {
 	*sql.Rows
+
+	release func() // Returns the connection of the rows to its pool, called once the rows are done
+}
+
+// Next prepares the next row, see sql.Rows.Next.
+func (r *Rows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	r.done()
+	return false
+}
+
+// Close closes the rows, see sql.Rows.Close.
+func (r *Rows) Close() error {
+	err := r.Rows.Close()
+	r.done()
+	return err
+}
+
+func (r *Rows) done() {
+	if r.release != nil {
+		r.release()
+		r.release = nil
+	}
 }
 
 var (
@@ -126,11 +151,16 @@ type Row interface {
 }
 
 type connRow struct {
-	rows *sql.Rows
-	err  error
+	rows    *sql.Rows
+	err     error
+	release func() // Returns the connection of the row to its pool once it's scanned
 }
 
 func (r *connRow) Scan(dest ...any) error {
+	if r.release != nil {
+		defer r.release()
+		r.release = nil
+	}
 	if r.err != nil {
 		return r.err
 	}
@@ -209,7 +239,7 @@ func (c *Conn) query(ctx context.Context, query string, args []any) (*Rows, erro
 		return nil, err
 	}
 
-	return &Rows{r}, nil
+	return &Rows{Rows: r}, nil
 }
 
 func (c *Conn) QueryRow(ctx context.Context, query string, args ...any) Row {
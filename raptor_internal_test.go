@@ -3,6 +3,7 @@ package raptor
 import (
 	"context"
 	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -71,3 +72,13 @@ func Test_txConn(t *testing.T) {
 		})
 	})
 }
+
+func TestNewWALPool_ClosesWriter(t *testing.T) {
+	p, err := NewWALPool(filepath.Join(t.TempDir(), "testing.db"), 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, p.writer.Len())
+
+	require.NoError(t, p.Close(context.Background()))
+	assert.Equal(t, 0, p.writer.Len())
+}
@@ -0,0 +1,133 @@
+package raptor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrReadOnlyTransaction = errors.New("raptor: write attempted in a read-only transaction")
+)
+
+// readTransact performs fn inside a read-only transaction.
+//
+// The transaction is always rolled back, and PRAGMA query_only is enabled for
+// its duration so SQLite rejects any write that isn't caught by ClassifyQuery.
+func (c *Conn) readTransact(ctx context.Context, fn func(DB) error) error {
+	sc, err := c.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer sc.Close()
+
+	tx := &readTxConn{conn: c, sc: sc}
+
+	var queryOnly bool
+	if err := tx.QueryRow(ctx, "PRAGMA query_only;").Scan(&queryOnly); err != nil {
+		return err
+	}
+	if !queryOnly {
+		if _, err := tx.exec(ctx, "PRAGMA query_only(1);"); err != nil {
+			return err
+		}
+		defer func() {
+			_, _ = tx.exec(context.WithoutCancel(ctx), "PRAGMA query_only(0);")
+		}()
+	}
+
+	if _, err := tx.exec(ctx, "BEGIN;"); err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = tx.exec(context.WithoutCancel(ctx), "ROLLBACK;")
+			panic(p)
+		}
+	}()
+
+	err = fn(tx)
+
+	if _, rErr := tx.exec(context.WithoutCancel(ctx), "ROLLBACK;"); rErr != nil {
+		if err == nil {
+			return rErr
+		}
+		return &TxRollbackError{Underlying: err, Rollback: rErr}
+	}
+	if errors.Is(err, ErrTxRollback) {
+		return nil
+	}
+	return err
+}
+
+// readTxConn is the DB passed to the function of a read-only transaction.
+type readTxConn struct {
+	conn *Conn
+	sc   *sql.Conn
+}
+
+var _ DB = (*readTxConn)(nil)
+
+func (t *readTxConn) checkQuery(ctx context.Context, query string) error {
+	kind := statementKindFromContext(ctx)
+	if kind == StatementKindUnknown {
+		kind = ClassifyQuery(query)
+	}
+	if kind == StatementKindWrite {
+		return fmt.Errorf("%w: %s", ErrReadOnlyTransaction, query)
+	}
+	return nil
+}
+
+func (t *readTxConn) exec(ctx context.Context, query string, args ...any) (Result, error) {
+	t.conn.queryLogger().LogQuery(ctx, query, args)
+
+	r, err := t.sc.ExecContext(ctx, query, args...)
+
+	return Result(r), err
+}
+
+func (t *readTxConn) Exec(ctx context.Context, query string, args ...any) (Result, error) {
+	if err := t.checkQuery(ctx, query); err != nil {
+		return nil, err
+	}
+
+	return t.exec(ctx, query, args...)
+}
+
+func (t *readTxConn) Query(ctx context.Context, query string, args ...any) (*Rows, error) {
+	if err := t.checkQuery(ctx, query); err != nil {
+		return nil, err
+	}
+
+	t.conn.queryLogger().LogQuery(ctx, query, args)
+
+	r, err := t.sc.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rows{Rows: r}, nil
+}
+
+func (t *readTxConn) QueryRow(ctx context.Context, query string, args ...any) Row {
+	if err := t.checkQuery(ctx, query); err != nil {
+		return &connRow{err: err}
+	}
+
+	t.conn.queryLogger().LogQuery(ctx, query, args)
+
+	r, err := t.sc.QueryContext(ctx, query, args...)
+
+	return &connRow{rows: r, err: err}
+}
+
+// Transact runs fn in the same read-only transaction, there is nothing to
+// commit so no savepoint is created.
+func (t *readTxConn) Transact(_ context.Context, fn func(DB) error) error {
+	if err := fn(t); !errors.Is(err, ErrTxRollback) {
+		return err
+	}
+	return nil
+}
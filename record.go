@@ -3,6 +3,7 @@ package raptor
 import (
 	"errors"
 	"reflect"
+	"strings"
 	"time"
 )
 
@@ -107,13 +108,14 @@ FieldLoop:
 		f := destT.Field(fi)
 
 		name := f.Name
-		switch f.Tag.Get("db") {
+		tag, _, _ := strings.Cut(f.Tag.Get("db"), ",") // Options after the name are used by statement.CreateTableFor
+		switch tag {
 		case "-":
 			continue FieldLoop
 		case "":
 			// no-op
 		default:
-			name = f.Tag.Get("db")
+			name = tag
 		}
 
 		fMap[name] = destE.FieldByName(f.Name)
@@ -158,13 +160,14 @@ FieldLoop:
 		f := srcType.Field(fi)
 
 		name := f.Name
-		switch f.Tag.Get("db") {
+		tag, _, _ := strings.Cut(f.Tag.Get("db"), ",") // Options after the name are used by statement.CreateTableFor
+		switch tag {
 		case "-":
 			continue FieldLoop
 		case "":
 			// no-op
 		default:
-			name = f.Tag.Get("db")
+			name = tag
 		}
 
 		rec[name] = rv.FieldByName(f.Name).Interface()
@@ -0,0 +1,71 @@
+package raptor
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"modernc.org/sqlite"
+)
+
+func init() {
+	// SQLite implements "X REGEXP Y" by calling regexp(Y, X). An error means the
+	// function has already been registered, which is left in place.
+	_ = sqlite.RegisterDeterministicScalarFunction("regexp", 2, sqliteRegexp)
+}
+
+// regexpCacheSize is the number of compiled patterns kept by sqliteRegexp.
+const regexpCacheSize = 128
+
+var regexpCache = struct {
+	sync.Mutex
+	patterns map[string]*regexp.Regexp
+}{patterns: make(map[string]*regexp.Regexp)}
+
+func compileRegexp(pattern string) (*regexp.Regexp, error) {
+	regexpCache.Lock()
+	defer regexpCache.Unlock()
+
+	if re, ok := regexpCache.patterns[pattern]; ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(regexpCache.patterns) >= regexpCacheSize {
+		clear(regexpCache.patterns)
+	}
+	regexpCache.patterns[pattern] = re
+
+	return re, nil
+}
+
+// sqliteRegexp reports if the value matches the pattern using Go regexp syntax.
+func sqliteRegexp(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	if args[0] == nil || args[1] == nil {
+		return nil, nil
+	}
+
+	pattern, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("raptor: regexp pattern must be text, got %T", args[0])
+	}
+
+	re, err := compileRegexp(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := args[1].(type) {
+	case string:
+		return re.MatchString(v), nil
+	case []byte:
+		return re.Match(v), nil
+	default:
+		return re.MatchString(fmt.Sprint(v)), nil
+	}
+}
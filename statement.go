@@ -3,6 +3,7 @@ package raptor
 import (
 	"context"
 
+	"github.com/maddiesch/go-raptor/statement"
 	"github.com/maddiesch/go-raptor/statement/generator"
 )
 
@@ -33,6 +34,55 @@ func ExecStatement(ctx context.Context, e Executor, stmt generator.Generator) (R
 	return e.Exec(ctx, query, args...)
 }
 
+// ExecStatements executes the statements in order inside a single transaction,
+// e.g. the Statements of a CreateTableBuilder with indexes.
+func ExecStatements(ctx context.Context, db TxBroker, stmts ...generator.Generator) error {
+	return db.Transact(ctx, func(tx DB) error {
+		for _, stmt := range stmts {
+			if _, err := ExecStatement(ctx, tx, stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// BulkInsert performs the insert inside a single transaction, split into as
+// many statements as needed to stay within SQLite's limit on bound arguments.
+//
+// It returns the total number of rows inserted. An insert given no rows does
+// nothing.
+func BulkInsert(ctx context.Context, db TxBroker, stmt *statement.InsertBuilder) (int64, error) {
+	chunks, err := stmt.Chunks()
+	if err != nil {
+		return 0, err
+	}
+	if len(chunks) == 0 {
+		return 0, nil
+	}
+
+	var count int64
+	err = db.Transact(ctx, func(tx DB) error {
+		for _, chunk := range chunks {
+			result, err := ExecStatement(ctx, tx, chunk)
+			if err != nil {
+				return err
+			}
+			n, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			count += n
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (c *Conn) QueryRowStatement(ctx context.Context, statement generator.Generator) Row {
 	query, args, err := statement.Generate()
 	if err != nil {
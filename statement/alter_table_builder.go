@@ -0,0 +1,118 @@
+package statement
+
+import (
+	"github.com/maddiesch/go-raptor/statement/dialect"
+	"github.com/maddiesch/go-raptor/statement/generator"
+	"github.com/maddiesch/go-raptor/statement/query"
+)
+
+func AlterTable(name string) *AlterTableBuilder {
+	return &AlterTableBuilder{tableName: name}
+}
+
+// AlterTableBuilder changes an existing table.
+//
+// SQLite only allows one change per ALTER TABLE statement, so a builder with
+// more than one change fails to generate, use Statements to get a statement for
+// each change.
+type AlterTableBuilder struct {
+	tableName string
+	changes   []alterTableChange
+}
+
+type alterTableChange struct {
+	tableName string
+	generate  func() (string, error)
+}
+
+func (b *AlterTableBuilder) change(fn func() (string, error)) *AlterTableBuilder {
+	b.changes = append(b.changes, alterTableChange{b.tableName, fn})
+
+	return b
+}
+
+// AddColumn adds the column to the table.
+//
+// SQLite doesn't allow adding a STORED generated column, a NOT NULL column
+// without a default, or a column that defaults to an expression or a
+// DefaultConstant.
+func (b *AlterTableBuilder) AddColumn(column *ColumnBuilder) *AlterTableBuilder {
+	return b.change(func() (string, error) {
+		if column.generated != nil && column.stored {
+			return "", buildError("ALTER TABLE", ErrInvalidSchema, "can't add STORED generated column %q to an existing table", column.name)
+		}
+		if !column.nullable && !column.hasAnyDefault() && column.generated == nil {
+			return "", buildError("ALTER TABLE", ErrInvalidSchema, "can't add NOT NULL column %q without a default to an existing table", column.name)
+		}
+		if _, ok := column.defaultValue.(DefaultConstant); ok || column.defaultExpr != nil {
+			return "", buildError("ALTER TABLE", ErrInvalidSchema, "can't add column %q with a non-constant default to an existing table", column.name)
+		}
+		c, _, err := column.Generate()
+		if err != nil {
+			return "", err
+		}
+		return "ADD COLUMN " + c, nil
+	})
+}
+
+func (b *AlterTableBuilder) RenameColumn(from, to string) *AlterTableBuilder {
+	return b.change(func() (string, error) {
+		return "RENAME COLUMN " + dialect.Identifier(from) + " TO " + dialect.Identifier(to), nil
+	})
+}
+
+func (b *AlterTableBuilder) DropColumn(name string) *AlterTableBuilder {
+	return b.change(func() (string, error) {
+		return "DROP COLUMN " + dialect.Identifier(name), nil
+	})
+}
+
+// RenameTo renames the table, changes added after it use the new name.
+func (b *AlterTableBuilder) RenameTo(name string) *AlterTableBuilder {
+	b.change(func() (string, error) {
+		return "RENAME TO " + dialect.Identifier(name), nil
+	})
+	b.tableName = name
+
+	return b
+}
+
+// Statements returns an ALTER TABLE statement for each change, in the order
+// they were added, e.g. for the UpStatements of a migration.
+func (b *AlterTableBuilder) Statements() []generator.Generator {
+	if len(b.changes) < 2 {
+		return []generator.Generator{b}
+	}
+
+	statements := make([]generator.Generator, len(b.changes))
+	for i, change := range b.changes {
+		statements[i] = &AlterTableBuilder{tableName: b.tableName, changes: []alterTableChange{change}}
+	}
+
+	return statements
+}
+
+func (b *AlterTableBuilder) Generate() (string, []any, error) {
+	if len(b.changes) == 0 {
+		return "", nil, missing("ALTER TABLE", "changes")
+	}
+	if len(b.changes) > 1 {
+		return "", nil, buildError("ALTER TABLE", ErrMultipleStatements, "%d changes need a statement each", len(b.changes))
+	}
+
+	change := b.changes[0]
+	if change.tableName == "" {
+		return "", nil, missing("ALTER TABLE", "table name")
+	}
+	c, err := change.generate()
+	if err != nil {
+		return "", nil, err
+	}
+
+	var query query.Builder
+	_, _ = query.WriteStringf("ALTER TABLE %s %s", dialect.Identifier(change.tableName), c)
+
+	return query.String(), nil, nil
+}
+
+var _ generator.Generator = (*AlterTableBuilder)(nil)
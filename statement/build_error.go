@@ -0,0 +1,61 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrIncompleteStatement is the error of a BuildError for a statement that is
+// missing a required part, such as the table of an UPDATE.
+var ErrIncompleteStatement = errors.New("statement: incomplete statement")
+
+// ErrMultipleStatements is the error of a BuildError for a definition that
+// needs more than one statement, such as a table with indexes. Its Statements
+// method returns each statement to be run in order.
+var ErrMultipleStatements = errors.New("statement: definition needs more than one statement")
+
+// BuildError is returned when generating a statement that is missing a
+// required part, or has parts that can't be used together.
+//
+// Err is the kind of problem, e.g. ErrIncompleteStatement or ErrInvalidSchema,
+// and Cause is the error that caused it, if any, so both can be checked with
+// errors.Is and errors.As.
+type BuildError struct {
+	// Statement is the kind of statement or definition, e.g. "SELECT" or "column".
+	Statement string
+	// Reason describes what is missing or inconsistent.
+	Reason string
+	Err    error
+	Cause  error
+}
+
+func (e *BuildError) Error() string {
+	return "statement: invalid " + e.Statement + ": " + e.Reason
+}
+
+func (e *BuildError) Unwrap() []error {
+	if e.Cause == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Err, e.Cause}
+}
+
+func buildError(statement string, err error, format string, args ...any) *BuildError {
+	return &BuildError{
+		Statement: statement,
+		Reason:    fmt.Sprintf(format, args...),
+		Err:       err,
+	}
+}
+
+// causedBy sets the error that caused the BuildError.
+func (e *BuildError) causedBy(err error) *BuildError {
+	e.Cause = err
+
+	return e
+}
+
+// missing returns an ErrIncompleteStatement BuildError for the missing part of the statement.
+func missing(statement string, part string) *BuildError {
+	return buildError(statement, ErrIncompleteStatement, "missing %s", part)
+}
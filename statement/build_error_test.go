@@ -0,0 +1,98 @@
+package statement_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/maddiesch/go-raptor/statement"
+	"github.com/maddiesch/go-raptor/statement/conditional"
+	"github.com/maddiesch/go-raptor/statement/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildError(t *testing.T) {
+	tests := map[string]struct {
+		statement         generator.Generator
+		expectedStatement string
+		expectedErr       error
+	}{
+		"select without from or columns": {statement.Select(), "SELECT", statement.ErrIncompleteStatement},
+		"select from empty table":        {statement.Select().From(""), "FROM clause", statement.ErrIncompleteStatement},
+		"select offset without limit":    {statement.Select().From("T").Offset(10), "SELECT", statement.ErrIncompleteStatement},
+		"insert without table":           {statement.Insert().Value("A", 1), "INSERT", statement.ErrIncompleteStatement},
+		"insert default values upsert":   {statement.Insert().Into("T").OnConflict().DoNothing(), "INSERT", statement.ErrIncompleteStatement},
+		"insert invalid rows":            {statement.Insert().Into("T").Rows([]int{1}), "INSERT", statement.ErrInvalidRows},
+		"update without table":           {statement.Update("").SetValue("A", 1), "UPDATE", statement.ErrIncompleteStatement},
+		"update without set":             {statement.Update("T").Where(conditional.Equal("A", 1)), "UPDATE", statement.ErrIncompleteStatement},
+		"update from with limit":         {statement.Update("T").SetValue("A", 1).From("O").Limit(1), "UPDATE", statement.ErrInvalidUpdate},
+		"update order without limit":     {statement.Update("T").SetValue("A", 1).OrderBy("A", true), "UPDATE", statement.ErrInvalidUpdate},
+		"delete without table":           {statement.Delete().Where(conditional.Equal("A", 1)), "DELETE", statement.ErrIncompleteStatement},
+		"create table without name":      {statement.CreateTable("").Column(statement.Column("A", statement.ColumnTypeText)), "CREATE TABLE", statement.ErrIncompleteStatement},
+		"create table without columns":   {statement.CreateTable("T"), "CREATE TABLE", statement.ErrIncompleteStatement},
+		"column without name":            {statement.CreateTable("T").Column(statement.Column("", statement.ColumnTypeText)), "column", statement.ErrIncompleteStatement},
+		"invalid column":                 {statement.CreateTable("T").Column(statement.Column("A", statement.ColumnTypeText).AutoIncrement()), "column", statement.ErrInvalidSchema},
+		"create index without columns":   {statement.CreateIndex("I").On("T"), "CREATE INDEX", statement.ErrIncompleteStatement},
+		"create index without table":     {statement.CreateIndex("I"), "CREATE INDEX", statement.ErrIncompleteStatement},
+		"drop without name":              {statement.DropIndex(""), "DROP INDEX", statement.ErrIncompleteStatement},
+		"alter table without changes":    {statement.AlterTable("T"), "ALTER TABLE", statement.ErrIncompleteStatement},
+		"alter table without name":       {statement.AlterTable("").DropColumn("A"), "ALTER TABLE", statement.ErrIncompleteStatement},
+		"with without statement":         {statement.With("A", statement.Select().From("T")), "WITH", statement.ErrIncompleteStatement},
+		"with without tables":            {new(statement.WithBuilder).Statement(statement.Select().From("T")), "WITH", statement.ErrIncompleteStatement},
+		"upsert where without set":       {statement.Insert().Into("T").Value("A", 1).OnConflict("A").Where(conditional.Equal("B", 1)), "INSERT", statement.ErrIncompleteStatement},
+		"upsert without action":          {statement.Insert().Into("T").Value("A", 1).OnConflict("A"), "INSERT", statement.ErrIncompleteStatement},
+		"upsert do nothing with where":   {statement.Insert().Into("T").Value("A", 1).OnConflict("A").Where(conditional.Equal("B", 1)).DoNothing(), "INSERT", statement.ErrIncompleteStatement},
+		"upsert without target":          {statement.Insert().Into("T").Value("A", 1).OnConflict().DoNothing().OnConflict("A").DoNothing(), "INSERT", statement.ErrInvalidInsert},
+		"nil where conditional":          {statement.Delete().From("T").Where(conditional.Or(nil, nil)), "WHERE clause", statement.ErrInvalidCondition},
+		"nil check conditional":          {statement.CreateTable("T").Column(statement.Column("A", statement.ColumnTypeText)).Check(conditional.And(nil, nil)), "CHECK constraint", statement.ErrInvalidCondition},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := test.statement.Generate()
+
+			var buildErr *statement.BuildError
+			require.ErrorAs(t, err, &buildErr)
+			assert.Equal(t, test.expectedStatement, buildErr.Statement)
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+
+	t.Run("message", func(t *testing.T) {
+		_, _, err := statement.Update("T").Generate()
+
+		assert.EqualError(t, err, "statement: invalid UPDATE: missing SET values")
+	})
+
+	t.Run("nil conditionals", func(t *testing.T) {
+		_, _, err := statement.Select().From("T").Where(conditional.And(nil, nil)).Generate()
+
+		var buildErr *statement.BuildError
+		require.ErrorAs(t, err, &buildErr)
+		assert.Equal(t, "WHERE clause", buildErr.Statement)
+		assert.ErrorIs(t, err, conditional.ErrNilConditional)
+	})
+
+	t.Run("cause", func(t *testing.T) {
+		_, _, err := statement.CreateTable("T").Column(statement.Column("A", statement.ColumnTypeText).DefaultValue(struct{}{})).Generate()
+
+		assert.ErrorIs(t, err, statement.ErrInvalidSchema)
+		assert.ErrorIs(t, err, generator.ErrUnsupportedArgument)
+
+		type invalidDefault struct {
+			Age int `db:",default=old"`
+		}
+		_, _, err = statement.CreateTableFor[invalidDefault]("T").Generate()
+
+		var numErr *strconv.NumError
+		assert.ErrorIs(t, err, statement.ErrInvalidSchema)
+		assert.ErrorAs(t, err, &numErr)
+	})
+
+	t.Run("select without from", func(t *testing.T) {
+		query, _, err := statement.Select().Columns(statement.Raw("1")).Generate()
+
+		require.NoError(t, err)
+		assert.Equal(t, `SELECT 1;`, query)
+	})
+}
@@ -0,0 +1,129 @@
+package statement
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/maddiesch/go-raptor/statement/generator"
+)
+
+// DefaultConstant is a default value that SQLite computes when a row is
+// inserted, for use with ColumnBuilder.DefaultValue.
+type DefaultConstant string
+
+const (
+	// DefaultCurrentTimestamp is the current UTC time as "YYYY-MM-DD HH:MM:SS" text.
+	DefaultCurrentTimestamp DefaultConstant = "CURRENT_TIMESTAMP"
+	// DefaultCurrentDate is the current UTC date as "YYYY-MM-DD" text.
+	DefaultCurrentDate DefaultConstant = "CURRENT_DATE"
+	// DefaultCurrentTime is the current UTC time as "HH:MM:SS" text.
+	DefaultCurrentTime DefaultConstant = "CURRENT_TIME"
+	// DefaultUnixEpoch is the current time as an integer number of seconds since the Unix epoch.
+	DefaultUnixEpoch DefaultConstant = "(unixepoch())"
+)
+
+// affinity is the type affinity of a column, see https://www.sqlite.org/datatype3.html.
+type affinity uint8
+
+const (
+	affinityNone affinity = iota // Untyped and ANY columns, any value is stored as is
+	affinityBlob
+	affinityText
+	affinityNumeric
+	affinityInteger
+	affinityReal
+)
+
+// columnAffinity returns the affinity SQLite gives a column of the type.
+//
+// SQLite gives a BLOB column no affinity, it's kept separate from untyped
+// columns so only blobs are accepted as its default.
+func columnAffinity(t ColumnType) affinity {
+	name := strings.ToUpper(string(t))
+
+	switch {
+	case name == "", t == ColumnTypeAny:
+		return affinityNone
+	case strings.Contains(name, "INT"):
+		return affinityInteger
+	case strings.Contains(name, "CHAR"), strings.Contains(name, "CLOB"), strings.Contains(name, "TEXT"):
+		return affinityText
+	case strings.Contains(name, "BLOB"):
+		return affinityBlob
+	case strings.Contains(name, "REAL"), strings.Contains(name, "FLOA"), strings.Contains(name, "DOUB"):
+		return affinityReal
+	default:
+		return affinityNumeric
+	}
+}
+
+// defaultValueAffinity returns the affinity matching the type of the value.
+func defaultValueAffinity(value any) (affinity, error) {
+	if v, ok := value.(driver.Valuer); ok {
+		var err error
+		if value, err = v.Value(); err != nil {
+			return 0, err
+		}
+	}
+
+	switch v := value.(type) {
+	case DefaultConstant:
+		if v == DefaultUnixEpoch {
+			return affinityInteger, nil
+		}
+		return affinityText, nil
+	case []byte:
+		return affinityBlob, nil
+	case time.Time:
+		return affinityText, nil
+	}
+
+	switch reflect.Indirect(reflect.ValueOf(value)).Kind() {
+	case reflect.String:
+		return affinityText, nil
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return affinityInteger, nil
+	case reflect.Float32, reflect.Float64:
+		return affinityReal, nil
+	default:
+		return 0, fmt.Errorf("%w: %T", generator.ErrUnsupportedArgument, value)
+	}
+}
+
+// compatible reports if a value with the affinity can be stored in the column
+// without being converted to another type.
+func (a affinity) compatible(column affinity) bool {
+	switch column {
+	case affinityNone:
+		return true
+	case affinityNumeric:
+		return a == affinityInteger || a == affinityReal
+	case affinityReal:
+		return a == affinityInteger || a == affinityReal
+	default:
+		return a == column
+	}
+}
+
+// defaultLiteral returns the DEFAULT clause value for the value.
+func defaultLiteral(value any) (string, error) {
+	if c, ok := value.(DefaultConstant); ok {
+		return string(c), nil
+	}
+
+	literal, err := generator.Literal(value)
+	if err != nil {
+		return "", err
+	}
+
+	// A string containing a NUL byte is written as a CAST expression.
+	if strings.HasPrefix(literal, "CAST(") {
+		literal = "(" + literal + ")"
+	}
+
+	return literal, nil
+}
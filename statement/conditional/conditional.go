@@ -1,16 +1,49 @@
 package conditional
 
 import (
-	"database/sql"
-
 	"github.com/maddiesch/go-raptor/statement/dialect"
 	"github.com/maddiesch/go-raptor/statement/generator"
 )
 
+// Conditional is a condition of a WHERE, HAVING or ON clause.
+//
+// A conditional that can fail implements FallibleConditional, and its Generate
+// method returns SQL that fails to prepare when it does, see generator.Invalid.
+// Use the package level Generate function to get the error instead.
 type Conditional interface {
 	Generate(generator.ArgumentNameProvider) (string, []any)
 }
 
+// FallibleConditional is implemented by conditionals that can fail to
+// generate, such as ones containing a subquery.
+type FallibleConditional interface {
+	Conditional
+
+	GenerateErr(generator.ArgumentNameProvider) (string, []any, error)
+}
+
+// Generate generates the conditional, returning the error of a FallibleConditional.
+func Generate(c Conditional, p generator.ArgumentNameProvider) (string, []any, error) {
+	if f, ok := c.(FallibleConditional); ok {
+		return f.GenerateErr(p)
+	}
+
+	s, args := c.Generate(p)
+
+	return s, args, nil
+}
+
+// MustGenerate generates the conditional, returning SQL that fails to prepare
+// when it fails, see generator.Invalid. It implements the Generate method of a
+// FallibleConditional.
+func MustGenerate(c FallibleConditional, p generator.ArgumentNameProvider) (string, []any) {
+	s, args, err := c.GenerateErr(p)
+	if err != nil {
+		return generator.Invalid(err), nil
+	}
+	return s, args
+}
+
 type Value any
 
 func ColumnName(column string) Conditional {
@@ -20,7 +53,7 @@ func ColumnName(column string) Conditional {
 type LiteralIdentifier string
 
 func (c LiteralIdentifier) Generate(generator.ArgumentNameProvider) (string, []any) {
-	return dialect.Identifier(string(c)), nil
+	return dialect.Column(string(c)), nil
 }
 
 func WrappedValue(value any) Conditional {
@@ -32,6 +65,6 @@ type wrappedValue struct {
 }
 
 func (v wrappedValue) Generate(p generator.ArgumentNameProvider) (string, []any) {
-	n := p.Next()
-	return "$" + n, []any{sql.Named(n, v.value)}
+	placeholder, arg := generator.Bind(p, v.value)
+	return placeholder, []any{arg}
 }
@@ -0,0 +1,149 @@
+package conditional
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/maddiesch/go-raptor/statement/dialect"
+	"github.com/maddiesch/go-raptor/statement/generator"
+)
+
+// JSONListThreshold is the number of values above which In and NotIn bind the
+// values as a single JSON array read with json_each, instead of one argument
+// per value, to stay under SQLite's limit on the number of arguments.
+//
+// Only strings, numbers and bools can be bound as a JSON array, a list with
+// values of any other type that's above the threshold returns ErrJSONListValue.
+//
+// Set to 0 to always bind one argument per value.
+var JSONListThreshold = 1000
+
+// ErrJSONListValue is returned when a value can't be bound as an element of a
+// JSON array, because it wouldn't compare equal to the same value bound as an
+// argument. For example a []byte is encoded as a base64 string.
+var ErrJSONListValue = errors.New("conditional: value can't be bound as a JSON list element")
+
+// In checks that the column is one of the values.
+//
+// A slice or array is expanded into one argument per element, any other value
+// is treated as a list of one. An empty list never matches.
+func In(column string, values Value) Conditional {
+	return &inConditional{column: column, values: values}
+}
+
+// NotIn checks that the column isn't one of the values.
+//
+// A slice or array is expanded into one argument per element, any other value
+// is treated as a list of one. An empty list always matches.
+func NotIn(column string, values Value) Conditional {
+	return &inConditional{column: column, values: values, negate: true}
+}
+
+// InJSON is the same as In, but always binds the values as a single JSON array.
+//
+// The values must be strings, numbers or bools.
+func InJSON(column string, values Value) Conditional {
+	return &inConditional{column: column, values: values, json: true}
+}
+
+// NotInJSON is the same as NotIn, but always binds the values as a single JSON array.
+func NotInJSON(column string, values Value) Conditional {
+	return &inConditional{column: column, values: values, negate: true, json: true}
+}
+
+type inConditional struct {
+	column string
+	values Value
+	negate bool
+	json   bool
+}
+
+func (c *inConditional) Generate(p generator.ArgumentNameProvider) (string, []any) {
+	return MustGenerate(c, p)
+}
+
+func (c *inConditional) GenerateErr(p generator.ArgumentNameProvider) (string, []any, error) {
+	values := expandValues(c.values)
+
+	operator := "IN"
+	if c.negate {
+		operator = "NOT IN"
+	}
+
+	if len(values) == 0 {
+		if c.negate {
+			return "TRUE", nil, nil
+		}
+		return "FALSE", nil, nil
+	}
+
+	if c.json || (JSONListThreshold > 0 && len(values) > JSONListThreshold) {
+		for _, v := range values {
+			if !isJSONListValue(v) {
+				return "", nil, fmt.Errorf("%w: %T", ErrJSONListValue, v)
+			}
+		}
+
+		list, err := json.Marshal(values)
+		if err != nil {
+			return "", nil, err
+		}
+		placeholder, arg := generator.Bind(p, string(list))
+
+		return fmt.Sprintf("%s %s (SELECT value FROM json_each(%s))", dialect.Column(c.column), operator, placeholder), []any{arg}, nil
+	}
+
+	placeholders := make([]string, len(values))
+	args := make([]any, len(values))
+	for i, v := range values {
+		placeholders[i], args[i] = generator.Bind(p, v)
+	}
+
+	return fmt.Sprintf("%s %s (%s)", dialect.Column(c.column), operator, strings.Join(placeholders, ", ")), args, nil
+}
+
+// expandValues returns the elements of a slice or array, a []byte is a single
+// blob value and isn't expanded.
+func expandValues(v Value) []any {
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return []any{v}
+		}
+		fallthrough
+	case reflect.Array:
+		values := make([]any, rv.Len())
+		for i := range values {
+			values[i] = rv.Index(i).Interface()
+		}
+		return values
+	default:
+		return []any{v}
+	}
+}
+
+// isJSONListValue reports if v is a string, number or bool, which json_each
+// returns as the same value the driver would bind.
+func isJSONListValue(v any) bool {
+	switch v.(type) {
+	case driver.Valuer, json.Marshaler, encoding.TextMarshaler:
+		return false
+	}
+
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
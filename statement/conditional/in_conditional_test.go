@@ -0,0 +1,108 @@
+package conditional_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/maddiesch/go-raptor/statement/conditional"
+	"github.com/maddiesch/go-raptor/statement/generator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditionalIn(t *testing.T) {
+	provider := generator.NewIncrementingArgumentNameProvider()
+
+	stmt, args := conditional.In("First", []int{1, 2, 3}).Generate(provider)
+
+	assert.Equal(t, `"First" IN ($v1, $v2, $v3)`, stmt)
+	assert.Equal(t, []any{sql.Named("v1", 1), sql.Named("v2", 2), sql.Named("v3", 3)}, args)
+
+	t.Run("given an array", func(t *testing.T) {
+		stmt, args := conditional.In("First", [2]string{"a", "b"}).Generate(generator.NewIncrementingArgumentNameProvider())
+
+		assert.Equal(t, `"First" IN ($v1, $v2)`, stmt)
+		assert.Equal(t, []any{sql.Named("v1", "a"), sql.Named("v2", "b")}, args)
+	})
+
+	t.Run("given a single value", func(t *testing.T) {
+		stmt, args := conditional.In("First", 1).Generate(generator.NewIncrementingArgumentNameProvider())
+
+		assert.Equal(t, `"First" IN ($v1)`, stmt)
+		assert.Equal(t, []any{sql.Named("v1", 1)}, args)
+	})
+
+	t.Run("given bytes", func(t *testing.T) {
+		stmt, args := conditional.In("First", []byte("foo")).Generate(generator.NewIncrementingArgumentNameProvider())
+
+		assert.Equal(t, `"First" IN ($v1)`, stmt)
+		assert.Equal(t, []any{sql.Named("v1", []byte("foo"))}, args)
+	})
+
+	t.Run("given an empty slice", func(t *testing.T) {
+		stmt, args := conditional.In("First", []int{}).Generate(generator.NewIncrementingArgumentNameProvider())
+
+		assert.Equal(t, `FALSE`, stmt)
+		assert.Len(t, args, 0)
+	})
+
+	t.Run("given more values than the threshold", func(t *testing.T) {
+		threshold := conditional.JSONListThreshold
+		conditional.JSONListThreshold = 2
+		t.Cleanup(func() {
+			conditional.JSONListThreshold = threshold
+		})
+
+		stmt, args := conditional.In("First", []int{1, 2, 3}).Generate(generator.NewIncrementingArgumentNameProvider())
+
+		assert.Equal(t, `"First" IN (SELECT value FROM json_each($v1))`, stmt)
+		assert.Equal(t, []any{sql.Named("v1", "[1,2,3]")}, args)
+	})
+
+	t.Run("given more values than the threshold that can't be bound as JSON", func(t *testing.T) {
+		blobs := make([][]byte, conditional.JSONListThreshold+1)
+		for i := range blobs {
+			blobs[i] = []byte{byte(i)}
+		}
+
+		_, _, err := conditional.Generate(conditional.In("First", blobs), generator.NewIncrementingArgumentNameProvider())
+		assert.ErrorIs(t, err, conditional.ErrJSONListValue)
+
+		times := make([]time.Time, conditional.JSONListThreshold+1)
+		for i := range times {
+			times[i] = time.Unix(int64(i), 0)
+		}
+
+		_, _, err = conditional.Generate(conditional.NotIn("First", times), generator.NewIncrementingArgumentNameProvider())
+		assert.ErrorIs(t, err, conditional.ErrJSONListValue)
+	})
+}
+
+func TestConditionalNotIn(t *testing.T) {
+	provider := generator.NewIncrementingArgumentNameProvider()
+
+	stmt, args := conditional.NotIn("First", []int{1, 2, 3}).Generate(provider)
+
+	assert.Equal(t, `"First" NOT IN ($v1, $v2, $v3)`, stmt)
+	assert.Equal(t, []any{sql.Named("v1", 1), sql.Named("v2", 2), sql.Named("v3", 3)}, args)
+
+	t.Run("given an empty slice", func(t *testing.T) {
+		stmt, args := conditional.NotIn("First", []int(nil)).Generate(generator.NewIncrementingArgumentNameProvider())
+
+		assert.Equal(t, `TRUE`, stmt)
+		assert.Len(t, args, 0)
+	})
+}
+
+func TestConditionalInJSON(t *testing.T) {
+	provider := generator.NewIncrementingArgumentNameProvider()
+
+	stmt, args := conditional.InJSON("First", []string{"a", "b"}).Generate(provider)
+
+	assert.Equal(t, `"First" IN (SELECT value FROM json_each($v1))`, stmt)
+	assert.Equal(t, []any{sql.Named("v1", `["a","b"]`)}, args)
+
+	stmt, _ = conditional.NotInJSON("First", []string{"a", "b"}).Generate(provider)
+
+	assert.Equal(t, `"First" NOT IN (SELECT value FROM json_each($v2))`, stmt)
+}
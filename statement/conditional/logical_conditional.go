@@ -1,11 +1,16 @@
 package conditional
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/maddiesch/go-raptor/statement/generator"
 )
 
+// ErrNilConditional is returned when generating a conditional that wraps a nil conditional.
+var ErrNilConditional = errors.New("conditional: nil conditional")
+
 func And(left, right Conditional) Conditional {
 	return &logicalInfixConditional{left, right, "AND"}
 }
@@ -14,14 +19,6 @@ func Or(left, right Conditional) Conditional {
 	return &logicalInfixConditional{left, right, "OR"}
 }
 
-func In(column string, values Value) Conditional {
-	return &logicalInfixConditional{ColumnName(column), WrappedValue(values), "IN"}
-}
-
-func NotIn(column string, values Value) Conditional {
-	return &logicalInfixConditional{ColumnName(column), WrappedValue(values), "NOT IN"}
-}
-
 type logicalInfixConditional struct {
 	left     Conditional
 	right    Conditional
@@ -29,22 +26,117 @@ type logicalInfixConditional struct {
 }
 
 func (c *logicalInfixConditional) Generate(provider generator.ArgumentNameProvider) (string, []any) {
+	return MustGenerate(c, provider)
+}
+
+func (c *logicalInfixConditional) GenerateErr(provider generator.ArgumentNameProvider) (string, []any, error) {
 	if c.left == nil && c.right == nil {
-		panic("must provide at least one non-nil conditional")
+		return "", nil, ErrNilConditional
 	}
 	if c.left == nil {
-		return c.right.Generate(provider)
+		return Generate(c.right, provider)
 	}
 	if c.right == nil {
-		return c.left.Generate(provider)
+		return Generate(c.left, provider)
 	}
 	var args []any
 
-	left, lArgs := c.left.Generate(provider)
+	left, lArgs, err := Generate(c.left, provider)
+	if err != nil {
+		return "", nil, err
+	}
 	args = append(args, lArgs...)
 
-	right, rArgs := c.right.Generate(provider)
+	right, rArgs, err := Generate(c.right, provider)
+	if err != nil {
+		return "", nil, err
+	}
 	args = append(args, rArgs...)
 
-	return fmt.Sprintf("(%s %s %s)", left, c.operator, right), args
+	return fmt.Sprintf("(%s %s %s)", left, c.operator, right), args, nil
+}
+
+// AndAll combines the conditionals with AND, skipping nil conditionals.
+//
+// It returns nil if every conditional is nil, so it can be passed to a Where
+// method to build a filter from optional parts.
+func AndAll(c ...Conditional) Conditional {
+	return newLogicalList("AND", c)
+}
+
+// OrAny combines the conditionals with OR, skipping nil conditionals.
+//
+// It returns nil if every conditional is nil, so it can be passed to a Where
+// method to build a filter from optional parts.
+func OrAny(c ...Conditional) Conditional {
+	return newLogicalList("OR", c)
+}
+
+func newLogicalList(operator string, c []Conditional) Conditional {
+	var children []Conditional
+	for _, child := range c {
+		if child != nil {
+			children = append(children, child)
+		}
+	}
+
+	switch len(children) {
+	case 0:
+		return nil
+	case 1:
+		return children[0]
+	default:
+		return &logicalListConditional{children, operator}
+	}
+}
+
+type logicalListConditional struct {
+	children []Conditional
+	operator string
+}
+
+func (c *logicalListConditional) Generate(provider generator.ArgumentNameProvider) (string, []any) {
+	return MustGenerate(c, provider)
+}
+
+func (c *logicalListConditional) GenerateErr(provider generator.ArgumentNameProvider) (string, []any, error) {
+	var args []any
+
+	parts := make([]string, len(c.children))
+	for i, child := range c.children {
+		s, cArgs, err := Generate(child, provider)
+		if err != nil {
+			return "", nil, err
+		}
+		parts[i] = s
+		args = append(args, cArgs...)
+	}
+
+	return "(" + strings.Join(parts, " "+c.operator+" ") + ")", args, nil
+}
+
+// Not negates the conditional.
+func Not(c Conditional) Conditional {
+	return &notConditional{c}
+}
+
+type notConditional struct {
+	child Conditional
+}
+
+func (c *notConditional) Generate(provider generator.ArgumentNameProvider) (string, []any) {
+	return MustGenerate(c, provider)
+}
+
+func (c *notConditional) GenerateErr(provider generator.ArgumentNameProvider) (string, []any, error) {
+	if c.child == nil {
+		return "", nil, ErrNilConditional
+	}
+
+	child, args, err := Generate(c.child, provider)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "NOT (" + child + ")", args, nil
 }
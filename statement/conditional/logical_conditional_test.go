@@ -93,9 +93,9 @@ func TestConditionalOr(t *testing.T) {
 	}
 
 	t.Run("when provided with no arguments", func(t *testing.T) {
-		assert.Panics(t, func() {
-			conditional.Or(nil, nil).Generate(provider)
-		})
+		_, _, err := conditional.Generate(conditional.Or(nil, nil), provider)
+
+		assert.ErrorIs(t, err, conditional.ErrNilConditional)
 	})
 
 	t.Run("given nil lhs", func(t *testing.T) {
@@ -129,24 +129,57 @@ func TestConditionalOr(t *testing.T) {
 	})
 }
 
-func TestConditionalIn(t *testing.T) {
+func TestConditionalAndAll(t *testing.T) {
+	t.Run("skips nil conditionals", func(t *testing.T) {
+		provider := generator.NewIncrementingArgumentNameProvider()
+
+		stmt, args := conditional.AndAll(
+			conditional.Equal("First", 1),
+			nil,
+			conditional.Equal("Second", 2),
+			conditional.Equal("Third", 3),
+		).Generate(provider)
+
+		assert.Equal(t, `("First" = $v1 AND "Second" = $v2 AND "Third" = $v3)`, stmt)
+		assert.Equal(t, []any{sql.Named("v1", 1), sql.Named("v2", 2), sql.Named("v3", 3)}, args)
+	})
+
+	t.Run("single conditional", func(t *testing.T) {
+		stmt, _ := conditional.AndAll(nil, conditional.Equal("First", 1)).Generate(generator.NewIncrementingArgumentNameProvider())
+
+		assert.Equal(t, `"First" = $v1`, stmt)
+	})
+
+	t.Run("only nil conditionals", func(t *testing.T) {
+		assert.Nil(t, conditional.AndAll(nil, nil))
+		assert.Nil(t, conditional.AndAll())
+	})
+}
+
+func TestConditionalOrAny(t *testing.T) {
 	provider := generator.NewIncrementingArgumentNameProvider()
 
-	stmt, args := conditional.In("First", []int{1, 2, 3}).Generate(provider)
+	stmt, _ := conditional.OrAny(
+		conditional.Equal("First", 1),
+		conditional.AndAll(conditional.Equal("Second", 2), conditional.Equal("Third", 3)),
+		nil,
+	).Generate(provider)
 
-	assert.Equal(t, `("First" IN $v1)`, stmt)
-	if assert.Len(t, args, 1) {
-		assert.Equal(t, sql.Named("v1", []int{1, 2, 3}), args[0])
-	}
+	assert.Equal(t, `("First" = $v1 OR ("Second" = $v2 AND "Third" = $v3))`, stmt)
+	assert.Nil(t, conditional.OrAny(nil))
 }
 
-func TestConditionalNotIn(t *testing.T) {
+func TestConditionalNot(t *testing.T) {
 	provider := generator.NewIncrementingArgumentNameProvider()
 
-	stmt, args := conditional.NotIn("First", []int{1, 2, 3}).Generate(provider)
+	stmt, args := conditional.Not(conditional.Equal("First", 1)).Generate(provider)
 
-	assert.Equal(t, `("First" NOT IN $v1)`, stmt)
-	if assert.Len(t, args, 1) {
-		assert.Equal(t, sql.Named("v1", []int{1, 2, 3}), args[0])
-	}
+	assert.Equal(t, `NOT ("First" = $v1)`, stmt)
+	assert.Equal(t, []any{sql.Named("v1", 1)}, args)
+
+	t.Run("nil conditional", func(t *testing.T) {
+		_, _, err := conditional.Generate(conditional.Not(nil), provider)
+
+		assert.ErrorIs(t, err, conditional.ErrNilConditional)
+	})
 }
@@ -1,7 +1,6 @@
 package conditional
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/maddiesch/go-raptor/statement/dialect"
@@ -38,6 +37,42 @@ func GreaterThanEq(col string, val any) Conditional {
 	return &operatorInfixConditional{col, ">=", val}
 }
 
+// Is compares the column with IS, which treats NULL values as equal.
+func Is(col string, val any) Conditional {
+	return &operatorInfixConditional{col, "IS", val}
+}
+
+// IsNot compares the column with IS NOT, which treats NULL values as equal.
+func IsNot(col string, val any) Conditional {
+	return &operatorInfixConditional{col, "IS NOT", val}
+}
+
+// IsDistinctFrom is true if the column and the value are different, treating
+// NULL values as equal. It's the same as IsNot.
+func IsDistinctFrom(col string, val any) Conditional {
+	return &operatorInfixConditional{col, "IS DISTINCT FROM", val}
+}
+
+// IsNotDistinctFrom is true if the column and the value are the same, treating
+// NULL values as equal. It's the same as Is.
+func IsNotDistinctFrom(col string, val any) Conditional {
+	return &operatorInfixConditional{col, "IS NOT DISTINCT FROM", val}
+}
+
+// Glob matches the column against a case sensitive pattern using Unix file
+// globbing syntax.
+func Glob(col string, pattern string) Conditional {
+	return &operatorInfixConditional{col, "GLOB", pattern}
+}
+
+// Regexp matches the column against a regular expression.
+//
+// SQLite doesn't implement REGEXP itself, raptor registers a regexp function
+// that uses the Go regexp package syntax.
+func Regexp(col string, pattern string) Conditional {
+	return &operatorInfixConditional{col, "REGEXP", pattern}
+}
+
 type operatorInfixConditional struct {
 	column   string
 	operator string
@@ -45,9 +80,38 @@ type operatorInfixConditional struct {
 }
 
 func (c *operatorInfixConditional) Generate(provider generator.ArgumentNameProvider) (string, []any) {
-	name := provider.Next()
+	placeholder, arg := generator.Bind(provider, c.value)
+
+	return fmt.Sprintf("%s %s %s", dialect.Column(c.column), c.operator, placeholder), []any{arg}
+}
+
+// Between is true if the column is between low and high, inclusive.
+func Between(col string, low, high any) Conditional {
+	return &betweenConditional{col, low, high, false}
+}
 
-	return fmt.Sprintf("%s %s $%s", dialect.Identifier(c.column), c.operator, name), []any{sql.Named(name, c.value)}
+// NotBetween is true if the column is less than low or greater than high.
+func NotBetween(col string, low, high any) Conditional {
+	return &betweenConditional{col, low, high, true}
+}
+
+type betweenConditional struct {
+	column string
+	low    any
+	high   any
+	negate bool
+}
+
+func (c *betweenConditional) Generate(provider generator.ArgumentNameProvider) (string, []any) {
+	operator := "BETWEEN"
+	if c.negate {
+		operator = "NOT BETWEEN"
+	}
+
+	low, lowArg := generator.Bind(provider, c.low)
+	high, highArg := generator.Bind(provider, c.high)
+
+	return fmt.Sprintf("%s %s %s AND %s", dialect.Column(c.column), operator, low, high), []any{lowArg, highArg}
 }
 
 func Null(col string) Conditional {
@@ -68,5 +132,45 @@ func (c *nullConditional) Generate(provider generator.ArgumentNameProvider) (str
 	if c.isNull {
 		v = "NULL"
 	}
-	return fmt.Sprintf("%s IS %s", dialect.Identifier(c.column), v), nil
+	return fmt.Sprintf("%s IS %s", dialect.Column(c.column), v), nil
+}
+
+// EqualColumn compares two columns with each other, e.g. in the ON clause of a join.
+func EqualColumn(left, right string) Conditional {
+	return &columnInfixConditional{left, "=", right}
+}
+
+// NotEqualColumn is true if the two columns are not equal.
+func NotEqualColumn(left, right string) Conditional {
+	return &columnInfixConditional{left, "!=", right}
+}
+
+// LessThanColumn is true if the left column is less than the right column.
+func LessThanColumn(left, right string) Conditional {
+	return &columnInfixConditional{left, "<", right}
+}
+
+// LessThanEqColumn is true if the left column is less than or equal to the right column.
+func LessThanEqColumn(left, right string) Conditional {
+	return &columnInfixConditional{left, "<=", right}
+}
+
+// GreaterThanColumn is true if the left column is greater than the right column.
+func GreaterThanColumn(left, right string) Conditional {
+	return &columnInfixConditional{left, ">", right}
+}
+
+// GreaterThanEqColumn is true if the left column is greater than or equal to the right column.
+func GreaterThanEqColumn(left, right string) Conditional {
+	return &columnInfixConditional{left, ">=", right}
+}
+
+type columnInfixConditional struct {
+	left     string
+	operator string
+	right    string
+}
+
+func (c *columnInfixConditional) Generate(generator.ArgumentNameProvider) (string, []any) {
+	return fmt.Sprintf("%s %s %s", dialect.Column(c.left), c.operator, dialect.Column(c.right)), nil
 }
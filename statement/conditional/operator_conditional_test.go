@@ -96,3 +96,64 @@ func TestNotNull(t *testing.T) {
 	assert.Equal(t, `"Test" IS NOT NULL`, out)
 	assert.Len(t, args, 0)
 }
+
+func TestEqualColumn(t *testing.T) {
+	pro := generator.NewIncrementingArgumentNameProvider()
+	out, args := conditional.EqualColumn("p.ID", "pet.ParentID").Generate(pro)
+
+	assert.Equal(t, `"p"."ID" = "pet"."ParentID"`, out)
+	assert.Len(t, args, 0)
+}
+
+func TestColumnComparisons(t *testing.T) {
+	tests := map[string]conditional.Conditional{
+		`"a" != "b"`: conditional.NotEqualColumn("a", "b"),
+		`"a" < "b"`:  conditional.LessThanColumn("a", "b"),
+		`"a" <= "b"`: conditional.LessThanEqColumn("a", "b"),
+		`"a" > "b"`:  conditional.GreaterThanColumn("a", "b"),
+		`"a" >= "b"`: conditional.GreaterThanEqColumn("a", "b"),
+	}
+
+	for expected, c := range tests {
+		t.Run(expected, func(t *testing.T) {
+			out, args := c.Generate(generator.NewIncrementingArgumentNameProvider())
+
+			assert.Equal(t, expected, out)
+			assert.Len(t, args, 0)
+		})
+	}
+}
+
+func TestValueOperators(t *testing.T) {
+	tests := map[string]conditional.Conditional{
+		`"Test" IS $v1`:                   conditional.Is("Test", "Foo"),
+		`"Test" IS NOT $v1`:               conditional.IsNot("Test", "Foo"),
+		`"Test" IS DISTINCT FROM $v1`:     conditional.IsDistinctFrom("Test", "Foo"),
+		`"Test" IS NOT DISTINCT FROM $v1`: conditional.IsNotDistinctFrom("Test", "Foo"),
+		`"Test" GLOB $v1`:                 conditional.Glob("Test", "Foo"),
+		`"Test" REGEXP $v1`:               conditional.Regexp("Test", "Foo"),
+	}
+
+	for expected, c := range tests {
+		t.Run(expected, func(t *testing.T) {
+			out, args := c.Generate(generator.NewIncrementingArgumentNameProvider())
+
+			assert.Equal(t, expected, out)
+			assert.Equal(t, []any{sql.Named("v1", "Foo")}, args)
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	pro := generator.NewIncrementingArgumentNameProvider()
+	out, args := conditional.Between("Age", 1, 5).Generate(pro)
+
+	assert.Equal(t, `"Age" BETWEEN $v1 AND $v2`, out)
+	assert.Equal(t, []any{sql.Named("v1", 1), sql.Named("v2", 5)}, args)
+
+	t.Run("not between", func(t *testing.T) {
+		out, _ := conditional.NotBetween("Age", 1, 5).Generate(pro)
+
+		assert.Equal(t, `"Age" NOT BETWEEN $v3 AND $v4`, out)
+	})
+}
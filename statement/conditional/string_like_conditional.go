@@ -1,37 +1,103 @@
 package conditional
 
 import (
-	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/maddiesch/go-raptor/statement/dialect"
 	"github.com/maddiesch/go-raptor/statement/generator"
 )
 
+// StringLike matches the column against the LIKE pattern, "%" and "_" in the
+// pattern are wildcards.
 func StringLike(column, value string) Conditional {
-	return &stringLikeConditional{column, value}
+	return &stringLikeConditional{column: column, value: value}
+}
+
+// NotLike is true if the column doesn't match the LIKE pattern.
+func NotLike(column, value string) Conditional {
+	return &stringLikeConditional{column: column, value: value, negate: true}
+}
+
+// StringHasPrefix is true if the column starts with the value, wildcards in
+// the value are escaped so they match literally.
+func StringHasPrefix(column, value string) Conditional {
+	return &stringLikeConditional{column: column, value: escapeLike(value) + `%`, escape: true}
+}
+
+// StringHasSuffix is true if the column ends with the value, wildcards in the
+// value are escaped so they match literally.
+func StringHasSuffix(column, value string) Conditional {
+	return &stringLikeConditional{column: column, value: `%` + escapeLike(value), escape: true}
+}
+
+// StringContains is true if the column contains the value, wildcards in the
+// value are escaped so they match literally.
+func StringContains(column, value string) Conditional {
+	return &stringLikeConditional{column: column, value: `%` + escapeLike(value) + `%`, escape: true}
+}
+
+// likeEscape is the escape character used by the helpers that escape their value.
+const likeEscape = `\`
+
+var likeEscaper = strings.NewReplacer(likeEscape, likeEscape+likeEscape, `%`, likeEscape+`%`, `_`, likeEscape+`_`)
+
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
 }
 
 type stringLikeConditional struct {
 	column string
 	value  string
+	negate bool
+	escape bool
+	noCase bool
 }
 
 func (c *stringLikeConditional) Generate(p generator.ArgumentNameProvider) (string, []any) {
-	name := p.Next()
+	placeholder, arg := generator.Bind(p, c.value)
+
+	column := dialect.Column(c.column)
+	if c.noCase {
+		column = "lower(" + column + ")"
+		placeholder = "lower(" + placeholder + ")"
+	}
+
+	operator := "LIKE"
+	if c.negate {
+		operator = "NOT LIKE"
+	}
 
-	return fmt.Sprintf("%s LIKE $%s", dialect.Identifier(c.column), name), []any{sql.Named(name, c.value)}
+	query := fmt.Sprintf("%s %s %s", column, operator, placeholder)
+	if c.escape {
+		query += " ESCAPE '" + likeEscape + "'"
+	}
+
+	return query, []any{arg}
 }
 
-func StringHasPrefix(column, value string) Conditional {
-	return StringLike(column, value+`%`)
+// caseInsensitive compares the lower case column and pattern, as COLLATE
+// doesn't apply to LIKE and PRAGMA case_sensitive_like can make it case
+// sensitive.
+func (c *stringLikeConditional) caseInsensitive() Conditional {
+	nc := *c
+	nc.noCase = true
+	return &nc
 }
 
-func StringHasSuffix(column, value string) Conditional {
-	return StringLike(column, `%`+value)
+// caseFolder is implemented by conditionals with their own case insensitive form.
+type caseFolder interface {
+	caseInsensitive() Conditional
 }
 
+// CaseInsensitive makes the comparison of the conditional case insensitive.
+//
+// Comparisons use COLLATE NOCASE, LIKE conditionals compare the lower case
+// column and pattern instead. Both only fold the case of ASCII characters.
 func CaseInsensitive(c Conditional) Conditional {
+	if f, ok := c.(caseFolder); ok {
+		return f.caseInsensitive()
+	}
 	return &caseInsensitiveConditional{c}
 }
 
@@ -40,7 +106,14 @@ type caseInsensitiveConditional struct {
 }
 
 func (c *caseInsensitiveConditional) Generate(p generator.ArgumentNameProvider) (string, []any) {
-	child, args := c.child.Generate(p)
+	return MustGenerate(c, p)
+}
+
+func (c *caseInsensitiveConditional) GenerateErr(p generator.ArgumentNameProvider) (string, []any, error) {
+	child, args, err := Generate(c.child, p)
+	if err != nil {
+		return "", nil, err
+	}
 
-	return child + " COLLATE NOCASE", args
+	return child + " COLLATE NOCASE", args, nil
 }
@@ -9,12 +9,30 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestStringLike(t *testing.T) {
+	provider := generator.NewIncrementingArgumentNameProvider()
+
+	str, args := conditional.StringLike("Foo", "B_r%").Generate(provider)
+
+	assert.Equal(t, `"Foo" LIKE $v1`, str)
+	assert.Equal(t, []any{sql.Named("v1", "B_r%")}, args)
+}
+
+func TestNotLike(t *testing.T) {
+	provider := generator.NewIncrementingArgumentNameProvider()
+
+	str, args := conditional.NotLike("Foo", "B_r%").Generate(provider)
+
+	assert.Equal(t, `"Foo" NOT LIKE $v1`, str)
+	assert.Equal(t, []any{sql.Named("v1", "B_r%")}, args)
+}
+
 func TestStringHasPrefix(t *testing.T) {
 	provider := generator.NewIncrementingArgumentNameProvider()
 
 	str, args := conditional.StringHasPrefix("Foo", "Bar").Generate(provider)
 
-	assert.Equal(t, `"Foo" LIKE $v1`, str)
+	assert.Equal(t, `"Foo" LIKE $v1 ESCAPE '\'`, str)
 
 	if assert.Len(t, args, 1) {
 		assert.Equal(t, sql.Named("v1", "Bar%"), args[0])
@@ -26,13 +44,22 @@ func TestStringHasSuffix(t *testing.T) {
 
 	str, args := conditional.StringHasSuffix("Foo", "Bar").Generate(provider)
 
-	assert.Equal(t, `"Foo" LIKE $v1`, str)
+	assert.Equal(t, `"Foo" LIKE $v1 ESCAPE '\'`, str)
 
 	if assert.Len(t, args, 1) {
 		assert.Equal(t, sql.Named("v1", "%Bar"), args[0])
 	}
 }
 
+func TestStringContains(t *testing.T) {
+	provider := generator.NewIncrementingArgumentNameProvider()
+
+	str, args := conditional.StringContains("Foo", `100%_\done`).Generate(provider)
+
+	assert.Equal(t, `"Foo" LIKE $v1 ESCAPE '\'`, str)
+	assert.Equal(t, []any{sql.Named("v1", `%100\%\_\\done%`)}, args)
+}
+
 func TestCaseInsensitive(t *testing.T) {
 	provider := generator.NewIncrementingArgumentNameProvider()
 
@@ -43,4 +70,17 @@ func TestCaseInsensitive(t *testing.T) {
 	if assert.Len(t, args, 1) {
 		assert.Equal(t, sql.Named("v1", "Bar"), args[0])
 	}
+
+	t.Run("like", func(t *testing.T) {
+		str, args := conditional.CaseInsensitive(conditional.StringHasPrefix("Foo", "Bar")).Generate(provider)
+
+		assert.Equal(t, `lower("Foo") LIKE lower($v2) ESCAPE '\'`, str)
+		assert.Equal(t, []any{sql.Named("v2", "Bar%")}, args)
+	})
+
+	t.Run("not like", func(t *testing.T) {
+		str, _ := conditional.CaseInsensitive(conditional.NotLike("Foo", "Bar")).Generate(provider)
+
+		assert.Equal(t, `lower("Foo") NOT LIKE lower($v3)`, str)
+	})
 }
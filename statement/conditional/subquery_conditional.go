@@ -0,0 +1,46 @@
+package conditional
+
+import (
+	"fmt"
+
+	"github.com/maddiesch/go-raptor/statement/dialect"
+	"github.com/maddiesch/go-raptor/statement/generator"
+)
+
+// InSelect checks the column against the values returned by a subquery.
+func InSelect(column string, sel generator.Fragment) Conditional {
+	return &subqueryConditional{format: dialect.Column(column) + " IN (%s)", sel: sel}
+}
+
+// NotInSelect checks the column isn't one of the values returned by a subquery.
+func NotInSelect(column string, sel generator.Fragment) Conditional {
+	return &subqueryConditional{format: dialect.Column(column) + " NOT IN (%s)", sel: sel}
+}
+
+// ExistsSelect checks that the subquery returns at least one row.
+func ExistsSelect(sel generator.Fragment) Conditional {
+	return &subqueryConditional{format: "EXISTS (%s)", sel: sel}
+}
+
+// NotExists checks that the subquery doesn't return any rows.
+func NotExists(sel generator.Fragment) Conditional {
+	return &subqueryConditional{format: "NOT EXISTS (%s)", sel: sel}
+}
+
+type subqueryConditional struct {
+	format string
+	sel    generator.Fragment
+}
+
+func (c *subqueryConditional) Generate(p generator.ArgumentNameProvider) (string, []any) {
+	return MustGenerate(c, p)
+}
+
+func (c *subqueryConditional) GenerateErr(p generator.ArgumentNameProvider) (string, []any, error) {
+	sub, args, err := c.sel.GenerateFragment(p)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf(c.format, sub), args, nil
+}
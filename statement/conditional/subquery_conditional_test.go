@@ -0,0 +1,80 @@
+package conditional_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/maddiesch/go-raptor/statement/conditional"
+	"github.com/maddiesch/go-raptor/statement/generator"
+	"github.com/stretchr/testify/assert"
+)
+
+type testFragment struct {
+	query string
+	value any
+	err   error
+}
+
+func (f *testFragment) GenerateFragment(p generator.ArgumentNameProvider) (string, []any, error) {
+	if f.err != nil {
+		return "", nil, f.err
+	}
+	name := p.Next()
+	return f.query + " $" + name, []any{sql.Named(name, f.value)}, nil
+}
+
+func TestInSelect(t *testing.T) {
+	provider := generator.NewIncrementingArgumentNameProvider()
+
+	str, args := conditional.And(
+		conditional.Equal("Foo", 1),
+		conditional.InSelect("ID", &testFragment{query: `SELECT "ID" FROM "Bar" WHERE "Baz" =`, value: 2}),
+	).Generate(provider)
+
+	assert.Equal(t, `("Foo" = $v1 AND "ID" IN (SELECT "ID" FROM "Bar" WHERE "Baz" = $v2))`, str)
+	assert.Equal(t, []any{sql.Named("v1", 1), sql.Named("v2", 2)}, args)
+}
+
+func TestNotInSelect(t *testing.T) {
+	provider := generator.NewIncrementingArgumentNameProvider()
+
+	str, _ := conditional.NotInSelect("ID", &testFragment{query: `SELECT "ID" FROM "Bar" WHERE "Baz" =`}).Generate(provider)
+
+	assert.Equal(t, `"ID" NOT IN (SELECT "ID" FROM "Bar" WHERE "Baz" = $v1)`, str)
+}
+
+func TestExistsSelect(t *testing.T) {
+	provider := generator.NewIncrementingArgumentNameProvider()
+
+	str, _ := conditional.ExistsSelect(&testFragment{query: `SELECT 1 FROM "Bar" WHERE "Baz" =`}).Generate(provider)
+
+	assert.Equal(t, `EXISTS (SELECT 1 FROM "Bar" WHERE "Baz" = $v1)`, str)
+
+	str, _ = conditional.NotExists(&testFragment{query: `SELECT 1 FROM "Bar" WHERE "Baz" =`}).Generate(provider)
+
+	assert.Equal(t, `NOT EXISTS (SELECT 1 FROM "Bar" WHERE "Baz" = $v2)`, str)
+}
+
+func TestGenerate(t *testing.T) {
+	targetErr := errors.New("fragment error")
+
+	_, _, err := conditional.Generate(conditional.CaseInsensitive(conditional.Or(
+		conditional.Equal("Foo", 1),
+		conditional.ExistsSelect(&testFragment{err: targetErr}),
+	)), generator.NewIncrementingArgumentNameProvider())
+
+	assert.ErrorIs(t, err, targetErr)
+}
+
+func TestGenerate_Invalid(t *testing.T) {
+	targetErr := errors.New("fragment error")
+
+	str, args := conditional.Or(
+		conditional.Equal("Foo", 1),
+		conditional.ExistsSelect(&testFragment{err: targetErr}),
+	).Generate(generator.NewIncrementingArgumentNameProvider())
+
+	assert.Equal(t, generator.Invalid(targetErr), str)
+	assert.Nil(t, args)
+}
@@ -0,0 +1,92 @@
+package statement
+
+import (
+	"strings"
+
+	"github.com/maddiesch/go-raptor/statement/conditional"
+	"github.com/maddiesch/go-raptor/statement/dialect"
+	"github.com/maddiesch/go-raptor/statement/generator"
+	"github.com/maddiesch/go-raptor/statement/query"
+)
+
+func CreateIndex(name string) *CreateIndexBuilder {
+	return &CreateIndexBuilder{indexName: name}
+}
+
+type CreateIndexBuilder struct {
+	indexName   string
+	tableName   string
+	columns     []string
+	unique      bool
+	ifNotExists bool
+	where       conditional.Conditional
+}
+
+// On sets the table and the columns that are indexed.
+func (b *CreateIndexBuilder) On(tableName string, columns ...string) *CreateIndexBuilder {
+	b.tableName = tableName
+	b.columns = columns
+
+	return b
+}
+
+func (b *CreateIndexBuilder) Unique() *CreateIndexBuilder {
+	b.unique = true
+
+	return b
+}
+
+func (b *CreateIndexBuilder) IfNotExists() *CreateIndexBuilder {
+	b.ifNotExists = true
+
+	return b
+}
+
+// Where creates a partial index of the rows matching the condition, its values
+// are written as literals.
+func (b *CreateIndexBuilder) Where(c conditional.Conditional) *CreateIndexBuilder {
+	b.where = c
+
+	return b
+}
+
+func (b *CreateIndexBuilder) Generate() (string, []any, error) {
+	switch {
+	case b.indexName == "":
+		return "", nil, missing("CREATE INDEX", "index name")
+	case b.tableName == "":
+		return "", nil, missing("CREATE INDEX", "table")
+	case len(b.columns) == 0:
+		return "", nil, missing("CREATE INDEX", "columns")
+	}
+
+	var query query.Builder
+
+	_, _ = query.WriteString("CREATE ")
+	if b.unique {
+		_, _ = query.WriteString("UNIQUE ")
+	}
+	_, _ = query.WriteString("INDEX ")
+	if b.ifNotExists {
+		_, _ = query.WriteString("IF NOT EXISTS ")
+	}
+
+	columns := make([]string, len(b.columns))
+	for i, c := range b.columns {
+		columns[i] = dialect.Identifier(c)
+	}
+
+	_, _ = query.WriteStringf("%s ON %s (%s)", dialect.Identifier(b.indexName), dialect.Identifier(b.tableName), strings.Join(columns, ", "))
+
+	if b.where != nil {
+		where, err := generateLiteral("WHERE clause", b.where)
+		if err != nil {
+			return "", nil, err
+		}
+		_, _ = query.WriteString(" WHERE " + where)
+	}
+
+	return query.String(), nil, nil
+}
+
+var _ generator.Generator = (*CreateIndexBuilder)(nil)
@@ -1,9 +1,12 @@
 package statement
 
 import (
+	"errors"
 	"strings"
 
+	"github.com/maddiesch/go-raptor/statement/conditional"
 	"github.com/maddiesch/go-raptor/statement/dialect"
+	"github.com/maddiesch/go-raptor/statement/generator"
 	"github.com/maddiesch/go-raptor/statement/query"
 )
 
@@ -22,15 +25,61 @@ const (
 	ColumnTypeInteger ColumnType = "INTEGER"
 	ColumnTypeFloat   ColumnType = "REAL"
 	ColumnTypeBlob    ColumnType = "BLOB"
+	ColumnTypeAny     ColumnType = "ANY" // Only allowed in a STRICT table
 )
 
+// ErrInvalidSchema is the error of a BuildError for a table or column
+// definition with constraints SQLite doesn't allow together.
+var ErrInvalidSchema = errors.New("statement: invalid schema")
+
+// strictTypes are the column types allowed in a STRICT table.
+var strictTypes = map[ColumnType]bool{
+	"INT":             true,
+	ColumnTypeInteger: true,
+	ColumnTypeFloat:   true,
+	ColumnTypeText:    true,
+	ColumnTypeBlob:    true,
+	ColumnTypeAny:     true,
+}
+
+// Collation is the name of a collating function used to compare text.
+//
+// A collation other than the built-in ones, such as one registered with the
+// driver, is quoted as an identifier.
+type Collation string
+
+const (
+	CollationBinary Collation = "BINARY"
+	CollationNoCase Collation = "NOCASE"
+	CollationRTrim  Collation = "RTRIM"
+)
+
+// generate returns the COLLATE clause for the collation.
+func (c Collation) generate() string {
+	switch c {
+	case CollationBinary, CollationNoCase, CollationRTrim:
+		return "COLLATE " + string(c)
+	default:
+		return "COLLATE " + dialect.Identifier(string(c))
+	}
+}
+
 type ColumnBuilder struct {
 	name           string
 	defaultLiteral string
+	defaultValue   any
+	hasDefault     bool // Set when the default is a value, which may be nil
+	defaultExpr    Expression
 	cType          ColumnType
 	nullable       bool
 	unique         bool
 	pk             bool
+	autoIncrement  bool
+	check          conditional.Conditional
+	collation      Collation
+	references     *referencesClause
+	generated      Expression
+	stored         bool
 }
 
 func (c *ColumnBuilder) NotNull() *ColumnBuilder {
@@ -45,13 +94,177 @@ func (c *ColumnBuilder) Unique() *ColumnBuilder {
 	return c
 }
 
+// Default sets the default of the column to the SQL literal, which is written
+// into the statement as is. Use DefaultValue or DefaultExpr to have the
+// default escaped and checked.
 func (c *ColumnBuilder) Default(literal string) *ColumnBuilder {
+	c.clearDefault()
 	c.defaultLiteral = literal
 
 	return c
 }
 
+// DefaultValue sets the default of the column to the value, written as an
+// escaped SQL literal. It can also be one of the DefaultConstant values.
+//
+// The value must match the type of the column, e.g. a string can't be the
+// default of an INTEGER column.
+func (c *ColumnBuilder) DefaultValue(value any) *ColumnBuilder {
+	c.clearDefault()
+	c.defaultValue = value
+	c.hasDefault = true
+
+	return c
+}
+
+// DefaultExpr sets the default of the column to the expression, its values are
+// written as literals.
+func (c *ColumnBuilder) DefaultExpr(expr Expression) *ColumnBuilder {
+	c.clearDefault()
+	c.defaultExpr = expr
+
+	return c
+}
+
+func (c *ColumnBuilder) clearDefault() {
+	c.defaultLiteral = ""
+	c.defaultValue = nil
+	c.hasDefault = false
+	c.defaultExpr = nil
+}
+
+func (c *ColumnBuilder) hasAnyDefault() bool {
+	return c.defaultLiteral != "" || c.hasDefault || c.defaultExpr != nil
+}
+
+// generateDefault returns the value of the DEFAULT clause, or an empty string if
+// the column doesn't have a default.
+func (c *ColumnBuilder) generateDefault() (string, error) {
+	switch {
+	case c.defaultLiteral != "":
+		return c.defaultLiteral, nil
+	case c.defaultExpr != nil:
+		expr, err := generateLiteral("DEFAULT expression", c.defaultExpr)
+		if err != nil {
+			return "", err
+		}
+		return "(" + expr + ")", nil
+	case c.hasDefault:
+		return defaultLiteral(c.defaultValue)
+	default:
+		return "", nil
+	}
+}
+
+// PrimaryKey makes the column the primary key of the table, use
+// CreateTableBuilder.PrimaryKeyColumns for a key with more than one column.
+func (c *ColumnBuilder) PrimaryKey() *ColumnBuilder {
+	c.pk = true
+	c.nullable = false
+
+	return c
+}
+
+// AutoIncrement makes the column an AUTOINCREMENT primary key, SQLite only
+// allows it on an INTEGER column.
+func (c *ColumnBuilder) AutoIncrement() *ColumnBuilder {
+	c.autoIncrement = true
+
+	return c.PrimaryKey()
+}
+
+// Check adds a CHECK constraint to the column, its values are written as literals.
+func (c *ColumnBuilder) Check(cond conditional.Conditional) *ColumnBuilder {
+	c.check = cond
+
+	return c
+}
+
+func (c *ColumnBuilder) Collate(collation Collation) *ColumnBuilder {
+	c.collation = collation
+
+	return c
+}
+
+// References makes the column a foreign key of the column in the table, if
+// the column is empty the primary key of the table is referenced.
+func (c *ColumnBuilder) References(table, column string) *ColumnBuilder {
+	c.references = &referencesClause{table: table}
+	if column != "" {
+		c.references.columns = []string{column}
+	}
+
+	return c
+}
+
+// OnDelete sets the action taken on the column when the referenced row is
+// deleted. It must be called after References.
+func (c *ColumnBuilder) OnDelete(action ForeignKeyAction) *ColumnBuilder {
+	if c.references != nil {
+		c.references.onDelete = action
+	}
+
+	return c
+}
+
+// OnUpdate sets the action taken on the column when the referenced key is
+// updated. It must be called after References.
+func (c *ColumnBuilder) OnUpdate(action ForeignKeyAction) *ColumnBuilder {
+	if c.references != nil {
+		c.references.onUpdate = action
+	}
+
+	return c
+}
+
+// GeneratedAs makes the column a generated column, computed from the
+// expression. A stored column is written when the row changes, otherwise it's
+// computed when it's read. The values of the expression are written as literals.
+func (c *ColumnBuilder) GeneratedAs(expr Expression, stored bool) *ColumnBuilder {
+	c.generated = expr
+	c.stored = stored
+
+	return c
+}
+
+func (c *ColumnBuilder) validate() error {
+	if c.name == "" {
+		return missing("column", "name")
+	}
+	if c.autoIncrement && c.cType != ColumnTypeInteger {
+		return buildError("column", ErrInvalidSchema, "AUTOINCREMENT column %q must be an INTEGER", c.name)
+	}
+	if c.generated != nil {
+		if c.pk {
+			return buildError("column", ErrInvalidSchema, "generated column %q can't be part of the primary key", c.name)
+		}
+		if c.hasAnyDefault() {
+			return buildError("column", ErrInvalidSchema, "generated column %q can't have a default value", c.name)
+		}
+	}
+	if c.hasDefault {
+		if c.defaultValue == nil {
+			if !c.nullable {
+				return buildError("column", ErrInvalidSchema, "NOT NULL column %q can't default to NULL", c.name)
+			}
+			return nil
+		}
+		a, err := defaultValueAffinity(c.defaultValue)
+		if err != nil {
+			return buildError("column", ErrInvalidSchema, "default of column %q: %v", c.name, err).causedBy(err)
+		}
+		if !a.compatible(columnAffinity(c.cType)) {
+			return buildError("column", ErrInvalidSchema, "default %v isn't compatible with %s column %q", c.defaultValue, c.cType, c.name)
+		}
+	}
+	return nil
+}
+
 func (c *ColumnBuilder) Generate() (string, []any, error) {
+	if err := c.validate(); err != nil {
+		return "", nil, err
+	}
+
 	var q query.Builder
 	_, _ = q.WriteString(dialect.Identifier(c.name))
 	_, _ = q.WriteRune(' ')
@@ -60,6 +273,9 @@ func (c *ColumnBuilder) Generate() (string, []any, error) {
 	if c.pk {
 		_, _ = q.WriteString(" PRIMARY KEY")
 	}
+	if c.autoIncrement {
+		_, _ = q.WriteString(" AUTOINCREMENT")
+	}
 
 	if !c.nullable {
 		_, _ = q.WriteString(" NOT NULL")
@@ -67,19 +283,56 @@ func (c *ColumnBuilder) Generate() (string, []any, error) {
 	if c.unique {
 		_, _ = q.WriteString(" UNIQUE")
 	}
-	if c.defaultLiteral != "" {
+	if c.hasAnyDefault() {
+		def, err := c.generateDefault()
+		if err != nil {
+			return "", nil, err
+		}
 		_, _ = q.WriteString(" DEFAULT ")
-		_, _ = q.WriteString(c.defaultLiteral)
+		_, _ = q.WriteString(def)
+	}
+	if c.generated != nil {
+		expr, err := generateLiteral("generated column", c.generated)
+		if err != nil {
+			return "", nil, err
+		}
+		_, _ = q.WriteString(" GENERATED ALWAYS AS (" + expr + ")")
+		if c.stored {
+			_, _ = q.WriteString(" STORED")
+		} else {
+			_, _ = q.WriteString(" VIRTUAL")
+		}
+	}
+	if c.check != nil {
+		check, err := generateLiteral("CHECK constraint", c.check)
+		if err != nil {
+			return "", nil, err
+		}
+		_, _ = q.WriteString(" CHECK (" + check + ")")
+	}
+	if c.collation != "" {
+		_, _ = q.WriteString(" " + c.collation.generate())
+	}
+	if c.references != nil {
+		_, _ = q.WriteString(" " + c.references.generate())
 	}
 
 	return q.Builder.String(), nil, nil
 }
 
 type CreateTableBuilder struct {
-	tableName   string
-	primaryKey  *ColumnBuilder
-	columns     []*ColumnBuilder
-	ifNotExists bool
+	tableName    string
+	pkColumn     *ColumnBuilder // Set by PrimaryKey, always the first column
+	primaryKey   []string
+	columns      []*ColumnBuilder
+	unique       [][]string
+	checks       []conditional.Conditional
+	foreignKeys  []*ForeignKeyBuilder
+	ifNotExists  bool
+	strict       bool
+	withoutRowID bool
+	indexes      [][]string
+	err          error
 }
 
 func (c *CreateTableBuilder) IfNotExists() *CreateTableBuilder {
@@ -88,19 +341,104 @@ func (c *CreateTableBuilder) IfNotExists() *CreateTableBuilder {
 	return c
 }
 
+// Strict makes the table a STRICT table, which rejects values that don't match
+// the column type. Every column must be INT, INTEGER, REAL, TEXT, BLOB or ANY.
+func (c *CreateTableBuilder) Strict() *CreateTableBuilder {
+	c.strict = true
+
+	return c
+}
+
+// WithoutRowID makes the table a WITHOUT ROWID table, which requires a primary key.
+func (c *CreateTableBuilder) WithoutRowID() *CreateTableBuilder {
+	c.withoutRowID = true
+
+	return c
+}
+
+// PrimaryKey adds a unique, NOT NULL primary key column as the first column
+// of the table.
+//
+// Deprecated: use Column(name, cType).PrimaryKey(), or PrimaryKeyColumns for a
+// key with more than one column.
 func (c *CreateTableBuilder) PrimaryKey(name string, cType ColumnType) *CreateTableBuilder {
-	c.primaryKey = &ColumnBuilder{name: name, cType: cType, nullable: false, unique: true, pk: true}
+	c.pkColumn = &ColumnBuilder{name: name, cType: cType, nullable: false, unique: true, pk: true}
+
+	return c
+}
+
+// PrimaryKeyColumns adds a PRIMARY KEY constraint on the columns to the table.
+func (c *CreateTableBuilder) PrimaryKeyColumns(columns ...string) *CreateTableBuilder {
+	c.primaryKey = columns
 
 	return c
 }
 
+// allColumns returns the columns of the table, starting with the column added
+// by PrimaryKey.
+func (c *CreateTableBuilder) allColumns() []*ColumnBuilder {
+	if c.pkColumn == nil {
+		return c.columns
+	}
+	return append([]*ColumnBuilder{c.pkColumn}, c.columns...)
+}
+
 func (c *CreateTableBuilder) Column(column ...*ColumnBuilder) *CreateTableBuilder {
 	c.columns = append(c.columns, column...)
 
 	return c
 }
 
+// Unique adds a UNIQUE constraint on the columns to the table.
+func (c *CreateTableBuilder) Unique(columns ...string) *CreateTableBuilder {
+	c.unique = append(c.unique, columns)
+
+	return c
+}
+
+// Index creates an index on the columns after the table is created, the index
+// is one of the table's Statements.
+//
+// The index is named after the table and its columns, e.g. "People_LastName_index".
+func (c *CreateTableBuilder) Index(columns ...string) *CreateTableBuilder {
+	c.indexes = append(c.indexes, columns)
+
+	return c
+}
+
+// Check adds a CHECK constraint to the table, its values are written as literals.
+func (c *CreateTableBuilder) Check(cond conditional.Conditional) *CreateTableBuilder {
+	c.checks = append(c.checks, cond)
+
+	return c
+}
+
+func (c *CreateTableBuilder) ForeignKey(fk ...*ForeignKeyBuilder) *CreateTableBuilder {
+	c.foreignKeys = append(c.foreignKeys, fk...)
+
+	return c
+}
+
+// Generate generates the CREATE TABLE statement.
+//
+// Columns are written in the order they were added, followed by the table
+// constraints: the primary key, then unique, check and foreign key constraints
+// in the order they were added. The output only depends on the definition, so
+// generated schemas can be compared.
+//
+// A table with indexes fails to generate, as each index is created by its own
+// statement, use Statements instead.
 func (c *CreateTableBuilder) Generate() (string, []any, error) {
+	if c.err != nil {
+		return "", nil, c.err
+	}
+	if err := c.validate(); err != nil {
+		return "", nil, err
+	}
+	if len(c.indexes) > 0 {
+		return "", nil, buildError("CREATE TABLE", ErrMultipleStatements, "table %q has indexes that need a statement each", c.tableName)
+	}
+
 	var query query.Builder
 
 	_, _ = query.WriteString("CREATE TABLE")
@@ -113,8 +451,8 @@ func (c *CreateTableBuilder) Generate() (string, []any, error) {
 	var args []any
 	var columns []string
 
-	if c.primaryKey != nil {
-		sub, sArgs, err := c.primaryKey.Generate()
+	for _, column := range c.allColumns() {
+		sub, sArgs, err := column.Generate()
 		if err != nil {
 			return "", nil, err
 		}
@@ -122,18 +460,94 @@ func (c *CreateTableBuilder) Generate() (string, []any, error) {
 		args = append(args, sArgs...)
 	}
 
-	for _, column := range c.columns {
-		sub, sArgs, err := column.Generate()
+	if len(c.primaryKey) > 0 {
+		columns = append(columns, "PRIMARY KEY ("+identifierList(c.primaryKey)+")")
+	}
+	for _, u := range c.unique {
+		columns = append(columns, "UNIQUE ("+identifierList(u)+")")
+	}
+	for _, check := range c.checks {
+		sub, err := generateLiteral("CHECK constraint", check)
 		if err != nil {
 			return "", nil, err
 		}
-		columns = append(columns, sub)
-		args = append(args, sArgs...)
+		columns = append(columns, "CHECK ("+sub+")")
+	}
+	for _, fk := range c.foreignKeys {
+		columns = append(columns, fk.generate())
 	}
 
 	_, _ = query.WriteString(strings.Join(columns, ", "))
 
 	_, _ = query.WriteString(")")
 
+	var options []string
+	if c.strict {
+		options = append(options, "STRICT")
+	}
+	if c.withoutRowID {
+		options = append(options, "WITHOUT ROWID")
+	}
+	if len(options) > 0 {
+		_, _ = query.WriteString(" " + strings.Join(options, ", "))
+	}
+
 	return query.String(), args, nil
 }
+
+// Statements returns the CREATE TABLE statement followed by a CREATE INDEX
+// statement for each index, e.g. for the UpStatements of a migration.
+func (c *CreateTableBuilder) Statements() []generator.Generator {
+	if len(c.indexes) == 0 {
+		return []generator.Generator{c}
+	}
+
+	table := *c
+	table.indexes = nil
+
+	statements := []generator.Generator{&table}
+	for _, columns := range c.indexes {
+		index := CreateIndex(c.tableName+"_"+strings.Join(columns, "_")+"_index").On(c.tableName, columns...)
+		if c.ifNotExists {
+			index.IfNotExists()
+		}
+		statements = append(statements, index)
+	}
+
+	return statements
+}
+
+func (c *CreateTableBuilder) validate() error {
+	if c.tableName == "" {
+		return missing("CREATE TABLE", "table name")
+	}
+	if len(c.allColumns()) == 0 {
+		return missing("CREATE TABLE", "columns")
+	}
+
+	hasPrimaryKey := len(c.primaryKey) > 0
+
+	for _, column := range c.allColumns() {
+		if column.pk {
+			if hasPrimaryKey {
+				return buildError("CREATE TABLE", ErrInvalidSchema, "table %q has more than one primary key", c.tableName)
+			}
+			hasPrimaryKey = true
+		}
+		if c.strict && !strictTypes[column.cType] {
+			return buildError("CREATE TABLE", ErrInvalidSchema, "column %q of STRICT table %q can't have type %q", column.name, c.tableName, column.cType)
+		}
+		if !c.strict && column.cType == ColumnTypeAny {
+			return buildError("CREATE TABLE", ErrInvalidSchema, "column %q can only have type ANY in a STRICT table", column.name)
+		}
+		if c.withoutRowID && column.autoIncrement {
+			return buildError("CREATE TABLE", ErrInvalidSchema, "WITHOUT ROWID table %q can't have an AUTOINCREMENT column", c.tableName)
+		}
+	}
+
+	if c.withoutRowID && !hasPrimaryKey {
+		return buildError("CREATE TABLE", ErrInvalidSchema, "WITHOUT ROWID table %q must have a primary key", c.tableName)
+	}
+
+	return nil
+}
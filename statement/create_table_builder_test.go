@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/maddiesch/go-raptor/statement"
+	"github.com/maddiesch/go-raptor/statement/conditional"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -28,4 +29,161 @@ func TestCreateTableBuilder(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, `CREATE TABLE IF NOT EXISTS "People" ("ID" TEXT PRIMARY KEY NOT NULL UNIQUE, "UserName" TEXT NOT NULL UNIQUE, "FirstName" TEXT NOT NULL, "CreatedAt" INTEGER NOT NULL DEFAULT CURRENT_TIMESTAMP);`, s)
 	})
+
+	t.Run("column primary key", func(t *testing.T) {
+		s, _, err := statement.CreateTable("People").Column(
+			statement.Column("FirstName", statement.ColumnTypeText).NotNull(),
+			statement.Column("ID", statement.ColumnTypeText).PrimaryKey(),
+		).Generate()
+
+		require.NoError(t, err)
+		assert.Equal(t, `CREATE TABLE "People" ("FirstName" TEXT NOT NULL, "ID" TEXT PRIMARY KEY NOT NULL);`, s)
+	})
+
+	t.Run("column constraints", func(t *testing.T) {
+		s, _, err := statement.CreateTable("Pets").Column(
+			statement.Column("ID", statement.ColumnTypeInteger).AutoIncrement(),
+			statement.Column("Name", statement.ColumnTypeText).NotNull().Collate(statement.CollationNoCase),
+			statement.Column("Age", statement.ColumnTypeInteger).Check(conditional.GreaterThanEq("Age", 0)),
+			statement.Column("ParentID", statement.ColumnTypeInteger).References("People", "ID").OnDelete(statement.Cascade).OnUpdate(statement.SetNull),
+		).Generate()
+
+		require.NoError(t, err)
+		assert.Equal(t, `CREATE TABLE "Pets" ("ID" INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL, "Name" TEXT NOT NULL COLLATE NOCASE, "Age" INTEGER CHECK ("Age" >= 0), "ParentID" INTEGER REFERENCES "People" ("ID") ON DELETE CASCADE ON UPDATE SET NULL);`, s)
+	})
+
+	t.Run("table constraints", func(t *testing.T) {
+		s, _, err := statement.CreateTable("Memberships").
+			ForeignKey(statement.ForeignKey("PersonID").References("People", "ID").OnDelete(statement.Cascade)).
+			Check(conditional.NotEqual("Role", "")).
+			Unique("PersonID", "Role").
+			PrimaryKeyColumns("PersonID", "GroupID").
+			Column(
+				statement.Column("PersonID", statement.ColumnTypeInteger).NotNull(),
+				statement.Column("GroupID", statement.ColumnTypeInteger).NotNull(),
+				statement.Column("Role", statement.ColumnTypeText).NotNull(),
+			).
+			ForeignKey(statement.ForeignKey("GroupID").References("Groups")).
+			Generate()
+
+		require.NoError(t, err)
+		assert.Equal(t, `CREATE TABLE "Memberships" ("PersonID" INTEGER NOT NULL, "GroupID" INTEGER NOT NULL, "Role" TEXT NOT NULL, PRIMARY KEY ("PersonID", "GroupID"), UNIQUE ("PersonID", "Role"), CHECK ("Role" != ''), FOREIGN KEY ("PersonID") REFERENCES "People" ("ID") ON DELETE CASCADE, FOREIGN KEY ("GroupID") REFERENCES "Groups");`, s)
+	})
+
+	t.Run("strict without rowid", func(t *testing.T) {
+		s, _, err := statement.CreateTable("Settings").Strict().WithoutRowID().Column(
+			statement.Column("Key", statement.ColumnTypeText).PrimaryKey(),
+			statement.Column("Value", statement.ColumnTypeAny),
+		).Generate()
+
+		require.NoError(t, err)
+		assert.Equal(t, `CREATE TABLE "Settings" ("Key" TEXT PRIMARY KEY NOT NULL, "Value" ANY) STRICT, WITHOUT ROWID;`, s)
+	})
+
+	t.Run("generated columns", func(t *testing.T) {
+		s, _, err := statement.CreateTable("People").Column(
+			statement.Column("FirstName", statement.ColumnTypeText),
+			statement.Column("LastName", statement.ColumnTypeText),
+			statement.Column("FullName", statement.ColumnTypeText).GeneratedAs(statement.Raw(`"FirstName" || ? || "LastName"`, " "), true),
+			statement.Column("Initial", statement.ColumnTypeText).GeneratedAs(statement.Raw(`substr("FirstName", 1, 1)`), false),
+		).Generate()
+
+		require.NoError(t, err)
+		assert.Equal(t, `CREATE TABLE "People" ("FirstName" TEXT, "LastName" TEXT, "FullName" TEXT GENERATED ALWAYS AS ("FirstName" || ' ' || "LastName") STORED, "Initial" TEXT GENERATED ALWAYS AS (substr("FirstName", 1, 1)) VIRTUAL);`, s)
+	})
+
+	t.Run("invalid combinations", func(t *testing.T) {
+		tests := map[string]*statement.CreateTableBuilder{
+			"strict type": statement.CreateTable("T").Strict().Column(
+				statement.Column("Name", statement.ColumnType("VARCHAR(10)")),
+			),
+			"any outside strict": statement.CreateTable("T").Column(
+				statement.Column("Value", statement.ColumnTypeAny),
+			),
+			"without rowid without primary key": statement.CreateTable("T").WithoutRowID().Column(
+				statement.Column("Name", statement.ColumnTypeText),
+			),
+			"without rowid autoincrement": statement.CreateTable("T").WithoutRowID().Column(
+				statement.Column("ID", statement.ColumnTypeInteger).AutoIncrement(),
+			),
+			"two primary keys": statement.CreateTable("T").PrimaryKeyColumns("Name").Column(
+				statement.Column("ID", statement.ColumnTypeInteger).PrimaryKey(),
+			),
+			"primary key column and constraint": statement.CreateTable("T").PrimaryKey("ID", statement.ColumnTypeInteger).PrimaryKeyColumns("ID", "Name").Column(
+				statement.Column("Name", statement.ColumnTypeText),
+			),
+			"autoincrement text": statement.CreateTable("T").Column(
+				statement.Column("ID", statement.ColumnTypeText).AutoIncrement(),
+			),
+			"generated primary key": statement.CreateTable("T").Column(
+				statement.Column("ID", statement.ColumnTypeInteger).PrimaryKey().GeneratedAs(statement.Raw("1"), true),
+			),
+			"generated default": statement.CreateTable("T").Column(
+				statement.Column("ID", statement.ColumnTypeInteger).Default("1").GeneratedAs(statement.Raw("1"), false),
+			),
+		}
+
+		for name, stmt := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, _, err := stmt.Generate()
+
+				assert.ErrorIs(t, err, statement.ErrInvalidSchema)
+			})
+		}
+	})
+
+	t.Run("default values", func(t *testing.T) {
+		s, _, err := statement.CreateTable("People").Column(
+			statement.Column("Name", statement.ColumnTypeText).NotNull().DefaultValue("it's"),
+			statement.Column("Age", statement.ColumnTypeInteger).NotNull().DefaultValue(-1),
+			statement.Column("Score", statement.ColumnTypeFloat).DefaultValue(1.5),
+			statement.Column("Avatar", statement.ColumnTypeBlob).DefaultValue([]byte{0xCA, 0xFE}),
+			statement.Column("Note", statement.ColumnTypeText).DefaultValue(nil),
+			statement.Column("CreatedAt", statement.ColumnTypeInteger).NotNull().DefaultValue(statement.DefaultUnixEpoch),
+			statement.Column("UpdatedAt", statement.ColumnTypeText).NotNull().DefaultValue(statement.DefaultCurrentTimestamp),
+			statement.Column("Token", statement.ColumnTypeText).DefaultExpr(statement.Raw("lower(hex(randomblob(?)))", 16)),
+		).Generate()
+
+		require.NoError(t, err)
+		assert.Equal(t, `CREATE TABLE "People" ("Name" TEXT NOT NULL DEFAULT 'it''s', "Age" INTEGER NOT NULL DEFAULT -1, "Score" REAL DEFAULT 1.5, "Avatar" BLOB DEFAULT X'CAFE', "Note" TEXT DEFAULT NULL, "CreatedAt" INTEGER NOT NULL DEFAULT (unixepoch()), "UpdatedAt" TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP, "Token" TEXT DEFAULT (lower(hex(randomblob(16)))));`, s)
+	})
+
+	t.Run("incompatible default values", func(t *testing.T) {
+		tests := map[string]*statement.ColumnBuilder{
+			"text in integer":      statement.Column("Age", statement.ColumnTypeInteger).DefaultValue("1"),
+			"timestamp in integer": statement.Column("CreatedAt", statement.ColumnTypeInteger).DefaultValue(statement.DefaultCurrentTimestamp),
+			"unix epoch in text":   statement.Column("CreatedAt", statement.ColumnTypeText).DefaultValue(statement.DefaultUnixEpoch),
+			"float in integer":     statement.Column("Age", statement.ColumnTypeInteger).DefaultValue(1.5),
+			"integer in blob":      statement.Column("Avatar", statement.ColumnTypeBlob).DefaultValue(1),
+			"null in not null":     statement.Column("Name", statement.ColumnTypeText).NotNull().DefaultValue(nil),
+			"unsupported value":    statement.Column("Name", statement.ColumnTypeText).DefaultValue(struct{}{}),
+		}
+
+		for name, column := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, _, err := column.Generate()
+
+				assert.ErrorIs(t, err, statement.ErrInvalidSchema)
+			})
+		}
+	})
+
+	t.Run("compatible default values", func(t *testing.T) {
+		tests := map[string]*statement.ColumnBuilder{
+			"integer in real":   statement.Column("Score", statement.ColumnTypeFloat).DefaultValue(1),
+			"text in varchar":   statement.Column("Name", statement.ColumnType("VARCHAR(10)")).DefaultValue("Maddie"),
+			"anything in any":   statement.Column("Value", statement.ColumnTypeAny).DefaultValue("Maddie"),
+			"number in numeric": statement.Column("Value", statement.ColumnType("DECIMAL(10,5)")).DefaultValue(1.5),
+			"bool in integer":   statement.Column("Enabled", statement.ColumnTypeInteger).DefaultValue(true),
+			"text in untyped":   statement.Column("Value", statement.ColumnType("")).DefaultValue("Maddie"),
+		}
+
+		for name, column := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, _, err := column.Generate()
+
+				assert.NoError(t, err)
+			})
+		}
+	})
 }
@@ -0,0 +1,222 @@
+package statement
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType   = reflect.TypeOf(time.Time{})
+	bytesType  = reflect.TypeOf([]byte(nil))
+	valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// CreateTableFor returns a CreateTableBuilder with a column for each exported
+// field of the struct T, using the same db tags as raptor.MarshalObject.
+//
+// The column type is derived from the field type. Fields are NOT NULL, unless
+// they are a pointer or a nullable type such as sql.NullString. Options can
+// follow the column name in the tag, e.g. `db:"Email,unique,notnull"`:
+//
+//   - pk: the field is part of the primary key
+//   - notnull: the column is NOT NULL even if the field is nullable
+//   - unique: the column is UNIQUE
+//   - index: an index is created on the column, see CreateTableBuilder.Statements
+//   - default=<value>: the default value of the column, parsed for the column
+//     type, or one of CURRENT_TIMESTAMP, CURRENT_DATE, CURRENT_TIME or unixepoch.
+//     It must be the last option, as the rest of the tag is its value, so the
+//     value can contain commas
+//   - type=<type>: overrides the derived column type
+//
+// A single integer pk field is the table's rowid. A zero value is inserted as
+// the key 0, so use a pointer field, such as *int64, for SQLite to assign the
+// key of a row inserted with a nil field.
+//
+// Unsigned integer fields are INTEGER columns, which hold 64-bit signed
+// integers, so a uint or uint64 value above math.MaxInt64 fails to insert.
+//
+// A field type that can't be mapped to a column type, or an invalid option, is
+// returned as an error by Generate.
+func CreateTableFor[T any](name string) *CreateTableBuilder {
+	b := CreateTable(name)
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		b.err = buildError("CREATE TABLE", ErrInvalidSchema, "%s must be a struct", t)
+		return b
+	}
+
+	var primaryKey []*ColumnBuilder
+	var pkNames []string
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		columnName, options := splitTag(f.Tag.Get("db"))
+		switch columnName {
+		case "-":
+			continue
+		case "":
+			columnName = f.Name
+		}
+
+		cType, nullable, ok := fieldColumnType(f.Type)
+
+		for _, option := range options {
+			if value, found := strings.CutPrefix(option, "type="); found {
+				cType, ok = ColumnType(value), true
+			}
+		}
+		if !ok {
+			b.err = buildError("CREATE TABLE", ErrInvalidSchema, "can't derive a column type for field %s of type %s", f.Name, f.Type)
+			return b
+		}
+
+		column := Column(columnName, cType)
+		if !nullable {
+			column.NotNull()
+		}
+
+		for _, option := range options {
+			key, value, _ := strings.Cut(option, "=")
+			switch key {
+			case "pk":
+				primaryKey = append(primaryKey, column)
+				pkNames = append(pkNames, columnName)
+			case "notnull":
+				column.NotNull()
+			case "unique":
+				column.Unique()
+			case "index":
+				b.Index(columnName)
+			case "type":
+			case "default":
+				def, err := parseDefault(value, cType, f.Type)
+				if err != nil {
+					b.err = buildError("CREATE TABLE", ErrInvalidSchema, "default of field %s: %v", f.Name, err).causedBy(err)
+					return b
+				}
+				column.DefaultValue(def)
+			default:
+				b.err = buildError("CREATE TABLE", ErrInvalidSchema, "unknown option %q on field %s", option, f.Name)
+				return b
+			}
+		}
+
+		b.Column(column)
+	}
+
+	switch len(primaryKey) {
+	case 0:
+	case 1:
+		primaryKey[0].PrimaryKey()
+	default:
+		b.PrimaryKeyColumns(pkNames...)
+	}
+
+	return b
+}
+
+// splitTag splits a db tag into the column name and its options. The value of
+// a default= option is the rest of the tag, so it can contain commas.
+func splitTag(tag string) (string, []string) {
+	name, rest, found := strings.Cut(tag, ",")
+
+	var options []string
+	for found {
+		if strings.HasPrefix(rest, "default=") {
+			options = append(options, rest)
+			break
+		}
+
+		var option string
+		option, rest, found = strings.Cut(rest, ",")
+		options = append(options, option)
+	}
+
+	return name, options
+}
+
+// fieldColumnType returns the column type for values of the field type, and if
+// the field can hold a NULL.
+func fieldColumnType(t reflect.Type) (ColumnType, bool, bool) {
+	if t.Kind() == reflect.Pointer {
+		cType, _, ok := fieldColumnType(t.Elem())
+		return cType, true, ok
+	}
+
+	switch t {
+	case timeType:
+		return ColumnTypeText, false, true
+	case bytesType:
+		return ColumnTypeBlob, true, true
+	}
+
+	// Nullable types such as sql.NullString and sql.Null[T] have a value field
+	// and a Valid field.
+	if t.Kind() == reflect.Struct && t.Implements(valuerType) && t.NumField() == 2 {
+		if valid, ok := t.FieldByName("Valid"); ok && valid.Type.Kind() == reflect.Bool {
+			value := t.Field(0)
+			if value.Name == "Valid" {
+				value = t.Field(1)
+			}
+			cType, _, ok := fieldColumnType(value.Type)
+			return cType, true, ok
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return ColumnTypeText, false, true
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return ColumnTypeInteger, false, true
+	case reflect.Float32, reflect.Float64:
+		return ColumnTypeFloat, false, true
+	default:
+		return "", false, false
+	}
+}
+
+// parseDefault parses the value of a default= tag option.
+func parseDefault(value string, cType ColumnType, fieldType reflect.Type) (any, error) {
+	switch strings.ToUpper(value) {
+	case "NULL":
+		return nil, nil
+	case string(DefaultCurrentTimestamp):
+		return DefaultCurrentTimestamp, nil
+	case string(DefaultCurrentDate):
+		return DefaultCurrentDate, nil
+	case string(DefaultCurrentTime):
+		return DefaultCurrentTime, nil
+	case "UNIXEPOCH", "UNIXEPOCH()":
+		return DefaultUnixEpoch, nil
+	}
+
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	switch columnAffinity(cType) {
+	case affinityInteger:
+		if fieldType.Kind() == reflect.Bool {
+			return strconv.ParseBool(value)
+		}
+		return strconv.ParseInt(value, 10, 64)
+	case affinityReal, affinityNumeric:
+		return strconv.ParseFloat(value, 64)
+	case affinityBlob:
+		return []byte(value), nil
+	default:
+		return value, nil
+	}
+}
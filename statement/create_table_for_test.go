@@ -0,0 +1,106 @@
+package statement_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/maddiesch/go-raptor/statement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTableFor(t *testing.T) {
+	t.Run("derives columns from fields", func(t *testing.T) {
+		type person struct {
+			ID        int64  `db:",pk"`
+			Email     string `db:"EmailAddress,unique"`
+			LastName  string `db:"LastName,index"`
+			Nickname  *string
+			Bio       sql.NullString
+			Age       int     `db:"Age,default=0"`
+			Score     float64 `db:",default=1.5"`
+			Admin     bool    `db:",default=false"`
+			Avatar    []byte
+			Metadata  string    `db:",type=ANY"`
+			CreatedAt time.Time `db:",default=CURRENT_TIMESTAMP"`
+			UpdatedAt *int64    `db:",notnull,default=unixepoch"`
+			Ignored   string    `db:"-"`
+			private   string
+		}
+
+		create := statement.CreateTableFor[person]("People").Strict()
+
+		_, _, err := create.Generate()
+		assert.ErrorIs(t, err, statement.ErrMultipleStatements)
+
+		assert.Equal(t, []string{`CREATE TABLE "People" (` +
+			`"ID" INTEGER PRIMARY KEY NOT NULL, ` +
+			`"EmailAddress" TEXT NOT NULL UNIQUE, ` +
+			`"LastName" TEXT NOT NULL, ` +
+			`"Nickname" TEXT, ` +
+			`"Bio" TEXT, ` +
+			`"Age" INTEGER NOT NULL DEFAULT 0, ` +
+			`"Score" REAL NOT NULL DEFAULT 1.5, ` +
+			`"Admin" INTEGER NOT NULL DEFAULT 0, ` +
+			`"Avatar" BLOB, ` +
+			`"Metadata" ANY NOT NULL, ` +
+			`"CreatedAt" TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP, ` +
+			`"UpdatedAt" INTEGER NOT NULL DEFAULT (unixepoch())` +
+			`) STRICT;`,
+			`CREATE INDEX "People_LastName_index" ON "People" ("LastName");`,
+		}, generateStatements(t, create.Statements()))
+	})
+
+	t.Run("composite primary key", func(t *testing.T) {
+		type membership struct {
+			PersonID int64 `db:",pk"`
+			GroupID  int64 `db:",pk"`
+		}
+
+		statements := statement.CreateTableFor[*membership]("Memberships").IfNotExists().Index("GroupID").Statements()
+
+		assert.Equal(t, []string{
+			`CREATE TABLE IF NOT EXISTS "Memberships" ("PersonID" INTEGER NOT NULL, "GroupID" INTEGER NOT NULL, PRIMARY KEY ("PersonID", "GroupID"));`,
+			`CREATE INDEX IF NOT EXISTS "Memberships_GroupID_index" ON "Memberships" ("GroupID");`,
+		}, generateStatements(t, statements))
+	})
+
+	t.Run("default containing commas", func(t *testing.T) {
+		type tagged struct {
+			Tags string `db:",unique,default=a,b"`
+		}
+
+		s, _, err := statement.CreateTableFor[tagged]("T").Generate()
+
+		require.NoError(t, err)
+		assert.Equal(t, `CREATE TABLE "T" ("Tags" TEXT NOT NULL UNIQUE DEFAULT 'a,b');`, s)
+	})
+
+	t.Run("invalid definitions", func(t *testing.T) {
+		type unsupported struct {
+			Tags []string
+		}
+		type unknownOption struct {
+			Name string `db:",primary"`
+		}
+		type invalidDefault struct {
+			Age int `db:",default=old"`
+		}
+
+		tests := map[string]*statement.CreateTableBuilder{
+			"not a struct":     statement.CreateTableFor[string]("T"),
+			"unsupported type": statement.CreateTableFor[unsupported]("T"),
+			"unknown option":   statement.CreateTableFor[unknownOption]("T"),
+			"invalid default":  statement.CreateTableFor[invalidDefault]("T"),
+		}
+
+		for name, stmt := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, _, err := stmt.Generate()
+
+				assert.ErrorIs(t, err, statement.ErrInvalidSchema)
+			})
+		}
+	})
+}
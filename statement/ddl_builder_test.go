@@ -0,0 +1,105 @@
+package statement_test
+
+import (
+	"testing"
+
+	"github.com/maddiesch/go-raptor/statement"
+	"github.com/maddiesch/go-raptor/statement/conditional"
+	"github.com/maddiesch/go-raptor/statement/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDDLBuilders(t *testing.T) {
+	tests := []struct {
+		statement     generator.Generator
+		expectedQuery string
+	}{
+		{
+			statement:     statement.CreateIndex("people_name").On("People", "LastName", "FirstName"),
+			expectedQuery: `CREATE INDEX "people_name" ON "People" ("LastName", "FirstName");`,
+		},
+		{
+			statement:     statement.CreateIndex("people_email").On("People", "Email").Unique().IfNotExists().Where(conditional.And(conditional.NotNull("Email"), conditional.NotEqual("Email", "it's"))),
+			expectedQuery: `CREATE UNIQUE INDEX IF NOT EXISTS "people_email" ON "People" ("Email") WHERE ("Email" IS NOT NULL AND "Email" != 'it''s');`,
+		},
+		{
+			statement:     statement.DropTable("People"),
+			expectedQuery: `DROP TABLE "People";`,
+		},
+		{
+			statement:     statement.DropTable("People").IfExists(),
+			expectedQuery: `DROP TABLE IF EXISTS "People";`,
+		},
+		{
+			statement:     statement.DropIndex("people_name").IfExists(),
+			expectedQuery: `DROP INDEX IF EXISTS "people_name";`,
+		},
+		{
+			statement:     statement.AlterTable("People").AddColumn(statement.Column("Age", statement.ColumnTypeInteger).NotNull().Default("0")),
+			expectedQuery: `ALTER TABLE "People" ADD COLUMN "Age" INTEGER NOT NULL DEFAULT 0;`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expectedQuery, func(t *testing.T) {
+			query, args, err := test.statement.Generate()
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expectedQuery, query)
+				assert.Empty(t, args)
+			}
+		})
+	}
+
+	t.Run("alter table statements", func(t *testing.T) {
+		alter := statement.AlterTable("People").RenameColumn("Age", "Years").DropColumn("Email").RenameTo("Person").DropColumn("Years")
+
+		_, _, err := alter.Generate()
+		assert.ErrorIs(t, err, statement.ErrMultipleStatements)
+
+		assert.Equal(t, []string{
+			`ALTER TABLE "People" RENAME COLUMN "Age" TO "Years";`,
+			`ALTER TABLE "People" DROP COLUMN "Email";`,
+			`ALTER TABLE "People" RENAME TO "Person";`,
+			`ALTER TABLE "Person" DROP COLUMN "Years";`,
+		}, generateStatements(t, alter.Statements()))
+	})
+
+	t.Run("add stored generated column", func(t *testing.T) {
+		_, _, err := statement.AlterTable("People").AddColumn(
+			statement.Column("FullName", statement.ColumnTypeText).GeneratedAs(statement.Raw(`"FirstName" || "LastName"`), true),
+		).Generate()
+
+		assert.ErrorIs(t, err, statement.ErrInvalidSchema)
+	})
+
+	t.Run("add columns that require a table rebuild", func(t *testing.T) {
+		tests := map[string]*statement.ColumnBuilder{
+			"not null without default": statement.Column("Age", statement.ColumnTypeInteger).NotNull(),
+			"constant default":         statement.Column("CreatedAt", statement.ColumnTypeInteger).DefaultValue(statement.DefaultUnixEpoch),
+			"expression default":       statement.Column("Token", statement.ColumnTypeText).DefaultExpr(statement.Raw("hex(randomblob(16))")),
+		}
+
+		for name, column := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, _, err := statement.AlterTable("People").AddColumn(column).Generate()
+
+				assert.ErrorIs(t, err, statement.ErrInvalidSchema)
+			})
+		}
+	})
+}
+
+// generateStatements generates each of the statements.
+func generateStatements(t *testing.T, statements []generator.Generator) []string {
+	t.Helper()
+
+	queries := make([]string, len(statements))
+	for i, s := range statements {
+		query, _, err := s.Generate()
+		require.NoError(t, err)
+		queries[i] = query
+	}
+
+	return queries
+}
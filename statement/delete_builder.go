@@ -14,6 +14,7 @@ func Delete() *DeleteBuilder {
 type DeleteBuilder struct {
 	tableName string
 	where     conditional.Conditional
+	returning *returningClause
 }
 
 func (b *DeleteBuilder) From(tableName string) *DeleteBuilder {
@@ -28,19 +29,49 @@ func (b *DeleteBuilder) Where(where conditional.Conditional) *DeleteBuilder {
 	return b
 }
 
+// Returning adds a RETURNING clause with the columns of the deleted rows,
+// calling it without any columns returns every column.
+func (b *DeleteBuilder) Returning(cols ...string) *DeleteBuilder {
+	b.returning = b.returning.add(returningColumns(cols...)...)
+
+	return b
+}
+
 func (b *DeleteBuilder) Generate() (string, []any, error) {
+	q, args, err := b.GenerateFragment(generator.NewArgumentNameProvider(generator.DefaultParameterStyle))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return q + ";", args, nil
+}
+
+// GenerateFragment generates the delete statement for use inside another statement.
+func (b *DeleteBuilder) GenerateFragment(provider generator.ArgumentNameProvider) (string, []any, error) {
+	if b.tableName == "" {
+		return "", nil, missing("DELETE", "table")
+	}
+
 	var query query.Builder
 	var args []any
 
 	_, _ = query.WriteStringf("DELETE FROM %s", dialect.Identifier(b.tableName))
 
-	provider := generator.NewIncrementingArgumentNameProvider()
-
 	if b.where != nil {
-		where, wArgs := b.where.Generate(provider)
+		where, wArgs, err := generateCondition("WHERE clause", b.where, provider)
+		if err != nil {
+			return "", nil, err
+		}
 		args = append(args, wArgs...)
 		_, _ = query.WriteStringf(" WHERE %s", where)
 	}
 
-	return query.String(), args, nil
+	_, _ = query.WriteString(b.returning.generate())
+
+	return query.Builder.String(), args, nil
 }
+
+var (
+	_ generator.Generator = (*DeleteBuilder)(nil)
+	_ generator.Fragment  = (*DeleteBuilder)(nil)
+)
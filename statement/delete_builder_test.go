@@ -22,4 +22,20 @@ func TestDeleteBuilder(t *testing.T) {
 			assert.Equal(t, sql.Named("v1", "Bar"), args[0])
 		}
 	})
+
+	t.Run("returning", func(t *testing.T) {
+		query, _, err := statement.Delete().From("TestTable").Where(conditional.Equal("Foo", "Bar")).Returning("ID", "Foo").Generate()
+
+		require.NoError(t, err)
+
+		assert.Equal(t, `DELETE FROM "TestTable" WHERE "Foo" = $v1 RETURNING "ID", "Foo";`, query)
+	})
+
+	t.Run("returning all columns", func(t *testing.T) {
+		query, _, err := statement.Delete().From("TestTable").Returning().Generate()
+
+		require.NoError(t, err)
+
+		assert.Equal(t, `DELETE FROM "TestTable" RETURNING *;`, query)
+	})
 }
@@ -1,9 +1,67 @@
 package dialect
 
 import (
-	"strconv"
+	"strings"
 )
 
-func Identifier(n string) string {
-	return strconv.Quote(n)
+// Identifier quotes each segment of a name, and joins them with a "."
+//
+// e.g. Identifier("p", "ID") returns "p"."ID"
+func Identifier(segments ...string) string {
+	quoted := make([]string, len(segments))
+
+	for i, s := range segments {
+		quoted[i] = `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+	}
+
+	return strings.Join(quoted, ".")
+}
+
+// Column quotes a column reference.
+//
+// A qualified reference such as "p.ID" is split on "." and quoted per segment,
+// and a "*" segment is left unquoted, so "p.*" returns "p".*
+//
+// A segment that is already wrapped in double quotes isn't split, so a column
+// whose name contains a "." can be referenced as `"a.b"` or `p."a.b"`.
+func Column(name string) string {
+	if name == "*" {
+		return name
+	}
+
+	segments := splitColumn(name)
+	if segments[len(segments)-1] == "*" {
+		return Identifier(segments[:len(segments)-1]...) + ".*"
+	}
+
+	return Identifier(segments...)
+}
+
+// splitColumn splits the reference on "." outside of double quoted segments,
+// and unquotes those segments.
+func splitColumn(name string) []string {
+	var segments []string
+	var segment strings.Builder
+	var quoted, inQuotes bool
+
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case inQuotes && c == '"' && i+1 < len(name) && name[i+1] == '"':
+			_ = segment.WriteByte('"')
+			i++
+		case inQuotes && c == '"':
+			inQuotes = false
+		case c == '"' && segment.Len() == 0 && !quoted && strings.IndexByte(name[i+1:], '"') >= 0:
+			inQuotes, quoted = true, true
+		case c == '.' && !inQuotes:
+			segments = append(segments, segment.String())
+			segment.Reset()
+			quoted = false
+		default:
+			_ = segment.WriteByte(c)
+		}
+	}
+
+	return append(segments, segment.String())
 }
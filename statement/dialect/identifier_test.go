@@ -9,4 +9,30 @@ import (
 
 func TestIdentifier(t *testing.T) {
 	assert.Equal(t, `"Foo"`, dialect.Identifier("Foo"))
+
+	t.Run("qualified", func(t *testing.T) {
+		assert.Equal(t, `"p"."ID"`, dialect.Identifier("p", "ID"))
+	})
+
+	t.Run("with a quote", func(t *testing.T) {
+		assert.Equal(t, `"Foo""Bar"`, dialect.Identifier(`Foo"Bar`))
+	})
+
+	t.Run("with a dot", func(t *testing.T) {
+		assert.Equal(t, `"com.maddiesch.kv-store"`, dialect.Identifier("com.maddiesch.kv-store"))
+	})
+}
+
+func TestColumn(t *testing.T) {
+	assert.Equal(t, `"ID"`, dialect.Column("ID"))
+	assert.Equal(t, `"p"."ID"`, dialect.Column("p.ID"))
+	assert.Equal(t, `*`, dialect.Column("*"))
+	assert.Equal(t, `"p".*`, dialect.Column("p.*"))
+
+	t.Run("quoted segment", func(t *testing.T) {
+		assert.Equal(t, `"a.b"`, dialect.Column(`"a.b"`))
+		assert.Equal(t, `"p"."a.b"`, dialect.Column(`p."a.b"`))
+		assert.Equal(t, `"p"."a""b.c"`, dialect.Column(`p."a""b.c"`))
+		assert.Equal(t, `"""a"`, dialect.Column(`"a`))
+	})
 }
@@ -0,0 +1,46 @@
+package statement
+
+import (
+	"github.com/maddiesch/go-raptor/statement/dialect"
+	"github.com/maddiesch/go-raptor/statement/generator"
+	"github.com/maddiesch/go-raptor/statement/query"
+)
+
+func DropTable(name string) *DropBuilder {
+	return &DropBuilder{kind: "TABLE", name: name}
+}
+
+func DropIndex(name string) *DropBuilder {
+	return &DropBuilder{kind: "INDEX", name: name}
+}
+
+// DropBuilder drops a table or an index.
+type DropBuilder struct {
+	kind     string
+	name     string
+	ifExists bool
+}
+
+func (b *DropBuilder) IfExists() *DropBuilder {
+	b.ifExists = true
+
+	return b
+}
+
+func (b *DropBuilder) Generate() (string, []any, error) {
+	if b.name == "" {
+		return "", nil, missing("DROP "+b.kind, "name")
+	}
+
+	var query query.Builder
+
+	_, _ = query.WriteString("DROP " + b.kind)
+	if b.ifExists {
+		_, _ = query.WriteString(" IF EXISTS")
+	}
+	_, _ = query.WriteString(" " + dialect.Identifier(b.name))
+
+	return query.String(), nil, nil
+}
+
+var _ generator.Generator = (*DropBuilder)(nil)
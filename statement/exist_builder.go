@@ -1,21 +1,38 @@
 package statement
 
 import (
-	"fmt"
-
 	"github.com/maddiesch/go-raptor/statement/generator"
 )
 
-func Exists(sel *SelectBuilder) generator.Generator {
-	return &existsQueryBuilder{sel}
+// Exists returns a statement that selects if the select has any rows.
+func Exists(sel *SelectBuilder) *ExistsBuilder {
+	return &ExistsBuilder{sel}
 }
 
-type existsQueryBuilder struct {
+type ExistsBuilder struct {
 	child *SelectBuilder
 }
 
-func (b *existsQueryBuilder) Generate() (string, []any, error) {
-	child, args, err := b.child.Generate()
+func (b *ExistsBuilder) Generate() (string, []any, error) {
+	q, args, err := b.GenerateFragment(generator.NewArgumentNameProvider(generator.DefaultParameterStyle))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return q + ";", args, nil
+}
+
+// GenerateFragment generates the statement for use inside another statement.
+func (b *ExistsBuilder) GenerateFragment(provider generator.ArgumentNameProvider) (string, []any, error) {
+	child, args, err := b.child.GenerateFragment(provider)
+	if err != nil {
+		return "", nil, err
+	}
 
-	return fmt.Sprintf("SELECT EXISTS(%s);", child[:len(child)-1]), args, err
+	return "SELECT EXISTS(" + child + ")", args, nil
 }
+
+var (
+	_ generator.Generator = (*ExistsBuilder)(nil)
+	_ generator.Fragment  = (*ExistsBuilder)(nil)
+)
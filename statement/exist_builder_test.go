@@ -6,6 +6,7 @@ import (
 
 	"github.com/maddiesch/go-raptor/statement"
 	"github.com/maddiesch/go-raptor/statement/conditional"
+	"github.com/maddiesch/go-raptor/statement/generator"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -21,4 +22,12 @@ func TestExists(t *testing.T) {
 	if assert.Len(t, args, 1) {
 		assert.Equal(t, sql.Named("v1", 1), args[0])
 	}
+
+	t.Run("with a parameter style", func(t *testing.T) {
+		query, args, err := generator.WithParameterStyle(statement.Exists(sel), generator.ParameterStylePositional).Generate()
+		require.NoError(t, err)
+
+		assert.Equal(t, `SELECT EXISTS(SELECT * FROM "Foo" WHERE "Bar" = ?);`, query)
+		assert.Equal(t, []any{1}, args)
+	})
 }
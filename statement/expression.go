@@ -0,0 +1,245 @@
+package statement
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/maddiesch/go-raptor/statement/conditional"
+	"github.com/maddiesch/go-raptor/statement/dialect"
+	"github.com/maddiesch/go-raptor/statement/generator"
+)
+
+// Expression is a SQL expression that can be used as a select column.
+//
+// It has the same method set as conditional.Conditional, so an expression can
+// also be used as a condition.
+//
+// An expression that can fail to generate, such as Raw, also implements
+// conditional.FallibleConditional.
+type Expression interface {
+	Generate(generator.ArgumentNameProvider) (string, []any)
+}
+
+// generateExpression generates the expression, returning the error of an
+// expression that can fail to generate.
+func generateExpression(e Expression, p generator.ArgumentNameProvider) (string, []any, error) {
+	return conditional.Generate(e, p)
+}
+
+// ColumnRef references a column, a qualified name such as "p.ID" is quoted per segment.
+func ColumnRef(name string) Expression {
+	return columnExpression(name)
+}
+
+type columnExpression string
+
+func (e columnExpression) Generate(generator.ArgumentNameProvider) (string, []any) {
+	return dialect.Column(string(e)), nil
+}
+
+// Count returns a COUNT aggregate of the column, use "*" to count rows.
+func Count(column string) Expression {
+	return &functionExpression{"COUNT", column}
+}
+
+// Sum returns a SUM aggregate of the column.
+func Sum(column string) Expression {
+	return &functionExpression{"SUM", column}
+}
+
+// Avg returns an AVG aggregate of the column.
+func Avg(column string) Expression {
+	return &functionExpression{"AVG", column}
+}
+
+// Min returns a MIN aggregate of the column.
+func Min(column string) Expression {
+	return &functionExpression{"MIN", column}
+}
+
+// Max returns a MAX aggregate of the column.
+func Max(column string) Expression {
+	return &functionExpression{"MAX", column}
+}
+
+type functionExpression struct {
+	name   string
+	column string
+}
+
+func (e *functionExpression) Generate(generator.ArgumentNameProvider) (string, []any) {
+	return e.name + "(" + dialect.Column(e.column) + ")", nil
+}
+
+// As aliases the expression.
+func As(expr Expression, alias string) Expression {
+	return &aliasExpression{expr, alias}
+}
+
+type aliasExpression struct {
+	expr  Expression
+	alias string
+}
+
+func (e *aliasExpression) Generate(p generator.ArgumentNameProvider) (string, []any) {
+	return conditional.MustGenerate(e, p)
+}
+
+func (e *aliasExpression) GenerateErr(p generator.ArgumentNameProvider) (string, []any, error) {
+	expr, args, err := generateExpression(e.expr, p)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return expr + " AS " + dialect.Identifier(e.alias), args, nil
+}
+
+// ErrRawArguments is returned when generating a Raw expression that doesn't
+// have an argument for every placeholder, or has more arguments than placeholders.
+var ErrRawArguments = errors.New("statement: raw argument count doesn't match its placeholders")
+
+// Raw returns an expression from literal SQL, e.g. as the computed value of an
+// update.
+//
+// Each "?" placeholder outside of string literals, quoted identifiers and
+// comments is bound to the matching argument, using the argument names of the
+// statement it's part of. The number of arguments must match the number of
+// placeholders.
+func Raw(query string, args ...any) Expression {
+	return &rawExpression{query, args}
+}
+
+type rawExpression struct {
+	query string
+	args  []any
+}
+
+func (e *rawExpression) Generate(p generator.ArgumentNameProvider) (string, []any) {
+	return conditional.MustGenerate(e, p)
+}
+
+func (e *rawExpression) GenerateErr(p generator.ArgumentNameProvider) (string, []any, error) {
+	var query strings.Builder
+	var args []any
+
+	// copyUntil copies the query up to and including end, starting at i.
+	copyUntil := func(i int, end string) int {
+		j := strings.Index(e.query[i:], end)
+		if j < 0 {
+			_, _ = query.WriteString(e.query[i:])
+			return len(e.query)
+		}
+		_, _ = query.WriteString(e.query[i : i+j+len(end)])
+		return i + j + len(end)
+	}
+
+	for i := 0; i < len(e.query); {
+		c := e.query[i]
+		switch {
+		case c == '-' && strings.HasPrefix(e.query[i:], "--"):
+			i = copyUntil(i, "\n")
+		case c == '/' && strings.HasPrefix(e.query[i:], "/*"):
+			i = copyUntil(i, "*/")
+		case c == '\'' || c == '"' || c == '`':
+			_ = query.WriteByte(c)
+			i = copyUntil(i+1, string(c))
+		case c == '[':
+			i = copyUntil(i, "]")
+		case c == '?':
+			if len(args) == len(e.args) {
+				return "", nil, fmt.Errorf("%w: %q has more than %d placeholders", ErrRawArguments, e.query, len(e.args))
+			}
+			placeholder, arg := generator.Bind(p, e.args[len(args)])
+			_, _ = query.WriteString(placeholder)
+			args = append(args, arg)
+			i++
+		default:
+			_ = query.WriteByte(c)
+			i++
+		}
+	}
+
+	if len(args) != len(e.args) {
+		return "", nil, fmt.Errorf("%w: %q has %d placeholders for %d arguments", ErrRawArguments, e.query, len(args), len(e.args))
+	}
+
+	return query.String(), args, nil
+}
+
+// Expr returns an expression from literal SQL, e.g. as the computed value of
+// an update. It's the same as Raw.
+func Expr(query string, args ...any) Expression {
+	return Raw(query, args...)
+}
+
+// Increment adds n to the column, e.g. `"Count" = "Count" + 1` as an update value.
+func Increment(column string, n any) Expression {
+	return &arithmeticExpression{column, "+", n}
+}
+
+// Decrement subtracts n from the column.
+func Decrement(column string, n any) Expression {
+	return &arithmeticExpression{column, "-", n}
+}
+
+type arithmeticExpression struct {
+	column   string
+	operator string
+	value    any
+}
+
+func (e *arithmeticExpression) Generate(p generator.ArgumentNameProvider) (string, []any) {
+	return conditional.MustGenerate(e, p)
+}
+
+func (e *arithmeticExpression) GenerateErr(p generator.ArgumentNameProvider) (string, []any, error) {
+	value, args, err := generateValue(p, e.value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return dialect.Column(e.column) + " " + e.operator + " " + value, args, nil
+}
+
+// Func calls the SQL function with the arguments, e.g. Func("unixepoch").
+//
+// An argument that is an Expression is used as is, any other argument is bound.
+func Func(name string, args ...any) Expression {
+	return &callExpression{name, args}
+}
+
+type callExpression struct {
+	name string
+	args []any
+}
+
+func (e *callExpression) Generate(p generator.ArgumentNameProvider) (string, []any) {
+	return conditional.MustGenerate(e, p)
+}
+
+func (e *callExpression) GenerateErr(p generator.ArgumentNameProvider) (string, []any, error) {
+	var args []any
+
+	values := make([]string, 0, len(e.args))
+	for _, a := range e.args {
+		value, vArgs, err := generateValue(p, a)
+		if err != nil {
+			return "", nil, err
+		}
+		values = append(values, value)
+		args = append(args, vArgs...)
+	}
+
+	return e.name + "(" + strings.Join(values, ", ") + ")", args, nil
+}
+
+// generateValue generates an Expression, or binds any other value.
+func generateValue(p generator.ArgumentNameProvider, value any) (string, []any, error) {
+	if expr, ok := value.(Expression); ok {
+		return generateExpression(expr, p)
+	}
+
+	placeholder, arg := generator.Bind(p, value)
+	return placeholder, []any{arg}, nil
+}
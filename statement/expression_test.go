@@ -0,0 +1,72 @@
+package statement_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/maddiesch/go-raptor/statement"
+	"github.com/maddiesch/go-raptor/statement/conditional"
+	"github.com/maddiesch/go-raptor/statement/generator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpression(t *testing.T) {
+	tests := []struct {
+		expression    statement.Expression
+		expectedQuery string
+		expectedArgs  []any
+	}{
+		{statement.ColumnRef("p.ID"), `"p"."ID"`, nil},
+		{statement.Count("*"), `COUNT(*)`, nil},
+		{statement.Sum("x"), `SUM("x")`, nil},
+		{statement.Avg("x"), `AVG("x")`, nil},
+		{statement.Min("x"), `MIN("x")`, nil},
+		{statement.Max("p.Age"), `MAX("p"."Age")`, nil},
+		{statement.As(statement.Max("Age"), "Oldest"), `MAX("Age") AS "Oldest"`, nil},
+		{statement.Raw(`COALESCE("Age", ?) + ?`, 0, 1), `COALESCE("Age", $v1) + $v2`, []any{sql.Named("v1", 0), sql.Named("v2", 1)}},
+		{statement.Raw(`'?' || ?`, "a"), `'?' || $v1`, []any{sql.Named("v1", "a")}},
+		{statement.Expr(`"Count" + ?`, 1), `"Count" + $v1`, []any{sql.Named("v1", 1)}},
+		{statement.Increment("Count", 2), `"Count" + $v1`, []any{sql.Named("v1", 2)}},
+		{statement.Decrement("t.Balance", statement.ColumnRef("o.Amount")), `"t"."Balance" - "o"."Amount"`, nil},
+		{statement.Func("unixepoch"), `unixepoch()`, nil},
+		{statement.Func("coalesce", statement.ColumnRef("Age"), 0), `coalesce("Age", $v1)`, []any{sql.Named("v1", 0)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expectedQuery, func(t *testing.T) {
+			query, args := test.expression.Generate(generator.NewIncrementingArgumentNameProvider())
+
+			assert.Equal(t, test.expectedQuery, query)
+			assert.Equal(t, test.expectedArgs, args)
+		})
+	}
+}
+
+func TestRaw(t *testing.T) {
+	t.Run("skips quoted identifiers and comments", func(t *testing.T) {
+		query, args, err := conditional.Generate(statement.Raw(`"a?" = ? AND [b?] = ? -- c?
+/* d? */ AND `+"`e?`"+` = 'f?'`, 1, 2), generator.NewIncrementingArgumentNameProvider())
+
+		require.NoError(t, err)
+		assert.Equal(t, `"a?" = $v1 AND [b?] = $v2 -- c?
+/* d? */ AND `+"`e?`"+` = 'f?'`, query)
+		assert.Equal(t, []any{sql.Named("v1", 1), sql.Named("v2", 2)}, args)
+	})
+
+	t.Run("argument count mismatch", func(t *testing.T) {
+		_, _, err := conditional.Generate(statement.Raw(`? + ?`, 1), generator.NewIncrementingArgumentNameProvider())
+		assert.ErrorIs(t, err, statement.ErrRawArguments)
+
+		_, _, err = conditional.Generate(statement.Raw(`1`, 1), generator.NewIncrementingArgumentNameProvider())
+		assert.ErrorIs(t, err, statement.ErrRawArguments)
+	})
+
+	t.Run("error from a nested expression", func(t *testing.T) {
+		_, _, err := statement.Select().Columns(statement.As(statement.Func("coalesce", statement.Raw(`?`)), "x")).Generate()
+		assert.ErrorIs(t, err, statement.ErrRawArguments)
+
+		_, _, err = statement.Update("T").SetValue("A", statement.Raw(`?`)).Generate()
+		assert.ErrorIs(t, err, statement.ErrRawArguments)
+	})
+}
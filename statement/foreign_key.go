@@ -0,0 +1,86 @@
+package statement
+
+import (
+	"strings"
+
+	"github.com/maddiesch/go-raptor/statement/dialect"
+)
+
+// ForeignKeyAction is performed when a referenced row is deleted or updated.
+type ForeignKeyAction string
+
+const (
+	NoAction   ForeignKeyAction = "NO ACTION"
+	Restrict   ForeignKeyAction = "RESTRICT"
+	SetNull    ForeignKeyAction = "SET NULL"
+	SetDefault ForeignKeyAction = "SET DEFAULT"
+	Cascade    ForeignKeyAction = "CASCADE"
+)
+
+// referencesClause is the REFERENCES clause of a column or table foreign key.
+type referencesClause struct {
+	table    string
+	columns  []string
+	onDelete ForeignKeyAction
+	onUpdate ForeignKeyAction
+}
+
+func (r *referencesClause) generate() string {
+	var query strings.Builder
+
+	_, _ = query.WriteString("REFERENCES " + dialect.Identifier(r.table))
+	if len(r.columns) > 0 {
+		_, _ = query.WriteString(" (" + identifierList(r.columns) + ")")
+	}
+	if r.onDelete != "" {
+		_, _ = query.WriteString(" ON DELETE " + string(r.onDelete))
+	}
+	if r.onUpdate != "" {
+		_, _ = query.WriteString(" ON UPDATE " + string(r.onUpdate))
+	}
+
+	return query.String()
+}
+
+// ForeignKey returns a table foreign key constraint for the columns.
+func ForeignKey(columns ...string) *ForeignKeyBuilder {
+	return &ForeignKeyBuilder{columns: columns}
+}
+
+type ForeignKeyBuilder struct {
+	columns    []string
+	references referencesClause
+}
+
+// References sets the referenced table and columns, if no columns are given the
+// primary key of the table is referenced.
+func (b *ForeignKeyBuilder) References(table string, columns ...string) *ForeignKeyBuilder {
+	b.references.table = table
+	b.references.columns = columns
+
+	return b
+}
+
+func (b *ForeignKeyBuilder) OnDelete(action ForeignKeyAction) *ForeignKeyBuilder {
+	b.references.onDelete = action
+
+	return b
+}
+
+func (b *ForeignKeyBuilder) OnUpdate(action ForeignKeyAction) *ForeignKeyBuilder {
+	b.references.onUpdate = action
+
+	return b
+}
+
+func (b *ForeignKeyBuilder) generate() string {
+	return "FOREIGN KEY (" + identifierList(b.columns) + ") " + b.references.generate()
+}
+
+func identifierList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = dialect.Identifier(n)
+	}
+	return strings.Join(quoted, ", ")
+}
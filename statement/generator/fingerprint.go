@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode"
+)
+
+// Fingerprint returns a key for the statement, e.g. to cache prepared
+// statements or group metrics by.
+//
+// Statements have the same fingerprint when they only differ by their argument
+// values, parameter style, comments or whitespace. Literals and the number of
+// arguments are kept, so a LIMIT of 1 and 10 have different fingerprints. Use
+// Normalize to group statements that only differ by those.
+func Fingerprint(gen Generator) (string, error) {
+	query, _, err := gen.Generate()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(normalize(query, true)))
+
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+// Normalize returns the shape of the query.
+//
+// Placeholders, string, blob and number literals are replaced by "?", and a
+// list of them is collapsed into one, so "IN ($v1, $v2)" and "IN (?)" are the
+// same. Comments and the trailing semicolon are removed, and whitespace is
+// collapsed into a single space. Quoted identifiers are left alone.
+//
+// The normalized query can't be run, and doesn't identify a single query, as
+// the literals and the number of arguments are lost.
+func Normalize(query string) string {
+	return normalize(query, false)
+}
+
+// normalize replaces placeholders by "?", and removes comments and extra
+// whitespace. Literals and lists of placeholders are only replaced when
+// literals is false.
+func normalize(query string, literals bool) string {
+	var out strings.Builder
+	var space bool
+
+	write := func(s string) {
+		if space && out.Len() > 0 {
+			_ = out.WriteByte(' ')
+		}
+		space = false
+		_, _ = out.WriteString(s)
+	}
+
+	writeLiteral := func(s string) {
+		if literals {
+			write(s)
+		} else {
+			write("?")
+		}
+	}
+
+	runes := []rune(query)
+	skipUntil := func(i int, end string) int {
+		e := []rune(end)
+		for ; i+len(e) <= len(runes); i++ {
+			if string(runes[i:i+len(e)]) == end {
+				return i + len(e)
+			}
+		}
+		return len(runes)
+	}
+	// skipQuoted skips a quoted string starting at i, where a doubled quote is an escaped quote.
+	skipQuoted := func(i int, quote rune) int {
+		for i++; i < len(runes); i++ {
+			if runes[i] != quote {
+				continue
+			}
+			if i+1 < len(runes) && runes[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+		return len(runes)
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			space = true
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			space = true
+			i = skipUntil(i+2, "\n")
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			space = true
+			i = skipUntil(i+2, "*/")
+		case r == '\'':
+			end := skipQuoted(i, r)
+			writeLiteral(string(runes[i:end]))
+			i = end
+		case (r == 'x' || r == 'X') && i+1 < len(runes) && runes[i+1] == '\'':
+			end := skipQuoted(i+1, '\'')
+			writeLiteral(string(runes[i:end]))
+			i = end
+		case r == '"' || r == '`':
+			end := skipQuoted(i, r)
+			write(string(runes[i:end]))
+			i = end
+		case r == '[':
+			end := skipUntil(i+1, "]")
+			write(string(runes[i:end]))
+			i = end
+		case r == '?':
+			for i++; i < len(runes) && unicode.IsDigit(runes[i]); i++ {
+			}
+			write("?")
+		case (r == '$' || r == ':' || r == '@') && i+1 < len(runes) && isParameterRune(runes[i+1]):
+			for i++; i < len(runes) && isParameterRune(runes[i]); i++ {
+			}
+			write("?")
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i++; i < len(runes); i++ {
+				if (runes[i] == '+' || runes[i] == '-') && (runes[i-1] == 'e' || runes[i-1] == 'E') {
+					continue
+				}
+				if !isParameterRune(runes[i]) && runes[i] != '.' {
+					break
+				}
+			}
+			writeLiteral(string(runes[start:i]))
+		case isParameterRune(r):
+			start := i
+			for i++; i < len(runes) && (isParameterRune(runes[i]) || runes[i] == '$'); i++ {
+			}
+			write(string(runes[start:i]))
+		default:
+			write(string(r))
+			i++
+		}
+	}
+
+	normalized := strings.TrimRight(out.String(), "; ")
+	if literals {
+		return normalized
+	}
+	for _, list := range [][2]string{{"?, ?", "?"}, {"?,?", "?"}, {"(?), (?)", "(?)"}, {"(?),(?)", "(?)"}} {
+		for strings.Contains(normalized, list[0]) {
+			normalized = strings.ReplaceAll(normalized, list[0], list[1])
+		}
+	}
+
+	return normalized
+}
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"database/sql"
 	"fmt"
 	"sync/atomic"
 )
@@ -13,12 +14,59 @@ type ArgumentNameProvider interface {
 	Next() string
 }
 
+// Binder is implemented by argument name providers that control how a value is
+// bound to a statement.
+type Binder interface {
+	// Bind returns the placeholder to write into the statement, and the
+	// argument to pass along with it.
+	Bind(value any) (string, any)
+}
+
+// Bind returns the placeholder and argument for the value.
+//
+// If the provider doesn't implement Binder the value is bound as a named
+// argument, e.g. "$v1" with sql.Named("v1", value).
+func Bind(p ArgumentNameProvider, value any) (string, any) {
+	if b, ok := p.(Binder); ok {
+		return b.Bind(value)
+	}
+
+	name := p.Next()
+
+	return "$" + name, sql.Named(name, value)
+}
+
+// ParameterStyle controls the placeholders a statement is generated with.
+type ParameterStyle uint8
+
+const (
+	// ParameterStyleNamed generates "$v1" placeholders, with sql.Named arguments.
+	ParameterStyleNamed ParameterStyle = iota
+	// ParameterStylePositional generates "?" placeholders, with plain arguments.
+	ParameterStylePositional
+	// ParameterStyleNumbered generates "?1" placeholders, with plain arguments.
+	ParameterStyleNumbered
+)
+
+// DefaultParameterStyle is the style used by the statement builders.
+//
+// It should only be changed before any statements are generated.
+var DefaultParameterStyle = ParameterStyleNamed
+
+// NewIncrementingArgumentNameProvider returns a provider that uses the named
+// parameter style.
 func NewIncrementingArgumentNameProvider() ArgumentNameProvider {
 	return &incrementingArgumentNameProvider{}
 }
 
+// NewArgumentNameProvider returns a provider that binds values using the style.
+func NewArgumentNameProvider(style ParameterStyle) ArgumentNameProvider {
+	return &incrementingArgumentNameProvider{style: style}
+}
+
 type incrementingArgumentNameProvider struct {
-	v atomic.Int64
+	v     atomic.Int64
+	style ParameterStyle
 }
 
 func (i *incrementingArgumentNameProvider) Next() string {
@@ -26,3 +74,55 @@ func (i *incrementingArgumentNameProvider) Next() string {
 
 	return fmt.Sprintf("v%d", value)
 }
+
+func (i *incrementingArgumentNameProvider) Bind(value any) (string, any) {
+	switch i.style {
+	case ParameterStylePositional:
+		i.v.Add(1)
+		return "?", value
+	case ParameterStyleNumbered:
+		return fmt.Sprintf("?%d", i.v.Add(1)), value
+	default:
+		name := i.Next()
+		return "$" + name, sql.Named(name, value)
+	}
+}
+
+// Fragment is implemented by statements that can be embedded in another
+// statement, such as a subquery.
+//
+// The fragment uses the argument name provider of the enclosing statement so
+// argument names never collide, and doesn't include a trailing semicolon.
+type Fragment interface {
+	GenerateFragment(ArgumentNameProvider) (string, []any, error)
+}
+
+// WithParameterStyle returns a generator for the statement that uses the style
+// instead of DefaultParameterStyle.
+func WithParameterStyle(stmt Fragment, style ParameterStyle) Generator {
+	return &styledGenerator{stmt, style}
+}
+
+type styledGenerator struct {
+	stmt  Fragment
+	style ParameterStyle
+}
+
+func (g *styledGenerator) Generate() (string, []any, error) {
+	q, args, err := g.stmt.GenerateFragment(NewArgumentNameProvider(g.style))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return q + ";", args, nil
+}
+
+// Invalid returns the SQL written by a Generate method that can't return the
+// error of its fragment.
+//
+// It starts with "!(", which SQLite never accepts, so a statement containing it
+// fails to prepare instead of running without the fragment. The builders use
+// the error returned by the fallible method instead.
+func Invalid(err error) string {
+	return "!(" + err.Error() + ")"
+}
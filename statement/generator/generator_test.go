@@ -1,9 +1,13 @@
 package generator
 
 import (
+	"database/sql"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_incrementingArgumentNameProvider(t *testing.T) {
@@ -14,3 +18,212 @@ func Test_incrementingArgumentNameProvider(t *testing.T) {
 		assert.Equal(t, "v2", p.Next())
 	})
 }
+
+func TestBind(t *testing.T) {
+	t.Run("named", func(t *testing.T) {
+		p := NewArgumentNameProvider(ParameterStyleNamed)
+
+		placeholder, arg := Bind(p, 1)
+
+		assert.Equal(t, "$v1", placeholder)
+		assert.Equal(t, sql.Named("v1", 1), arg)
+	})
+
+	t.Run("positional", func(t *testing.T) {
+		p := NewArgumentNameProvider(ParameterStylePositional)
+
+		placeholder, arg := Bind(p, 1)
+
+		assert.Equal(t, "?", placeholder)
+		assert.Equal(t, 1, arg)
+	})
+
+	t.Run("numbered", func(t *testing.T) {
+		p := NewArgumentNameProvider(ParameterStyleNumbered)
+
+		_, _ = Bind(p, 1)
+		placeholder, arg := Bind(p, 2)
+
+		assert.Equal(t, "?2", placeholder)
+		assert.Equal(t, 2, arg)
+	})
+
+	t.Run("provider without binder", func(t *testing.T) {
+		placeholder, arg := Bind(&constantProvider{"name"}, 1)
+
+		assert.Equal(t, "$name", placeholder)
+		assert.Equal(t, sql.Named("name", 1), arg)
+	})
+}
+
+type constantProvider struct {
+	name string
+}
+
+func (p *constantProvider) Next() string {
+	return p.name
+}
+
+type fragmentFunc func(ArgumentNameProvider) (string, []any, error)
+
+func (f fragmentFunc) GenerateFragment(p ArgumentNameProvider) (string, []any, error) {
+	return f(p)
+}
+
+func TestWithParameterStyle(t *testing.T) {
+	stmt := fragmentFunc(func(p ArgumentNameProvider) (string, []any, error) {
+		a, aArg := Bind(p, 1)
+		b, bArg := Bind(p, 2)
+		return "SELECT " + a + ", " + b, []any{aArg, bArg}, nil
+	})
+
+	query, args, err := WithParameterStyle(stmt, ParameterStyleNumbered).Generate()
+
+	require.NoError(t, err)
+
+	assert.Equal(t, "SELECT ?1, ?2;", query)
+	assert.Equal(t, []any{1, 2}, args)
+}
+
+func TestInterpolate(t *testing.T) {
+	tests := []struct {
+		query    string
+		args     []any
+		expected string
+	}{
+		{
+			query:    `SELECT * FROM "People" WHERE "FirstName" = $v1 AND "Age" > $v2;`,
+			args:     []any{sql.Named("v1", "Maddie"), sql.Named("v2", 30)},
+			expected: `SELECT * FROM "People" WHERE "FirstName" = 'Maddie' AND "Age" > 30;`,
+		},
+		{
+			query:    `SELECT ?, ?, ?;`,
+			args:     []any{"it's", nil, true},
+			expected: `SELECT 'it''s', NULL, 1;`,
+		},
+		{
+			query:    `SELECT ?2, ?1, ?;`,
+			args:     []any{1.5, []byte{0xCA, 0xFE}, float64(2)},
+			expected: `SELECT X'CAFE', 1.5, 2.0;`,
+		},
+		{
+			query:    `SELECT '?', "$v1", ? -- ?`,
+			args:     []any{"a\x00b"},
+			expected: `SELECT '?', "$v1", CAST(X'610062' AS TEXT) -- ?`,
+		},
+		{
+			query:    `SELECT :name, @name;`,
+			args:     []any{time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)},
+			expected: `SELECT '2023-04-05 06:07:08 +0000 UTC', '2023-04-05 06:07:08 +0000 UTC';`,
+		},
+		{
+			query:    `SELECT ?;`,
+			args:     []any{sql.NullString{}},
+			expected: `SELECT NULL;`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			query, err := Interpolate(test.query, test.args)
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, query)
+		})
+	}
+
+	t.Run("missing argument", func(t *testing.T) {
+		_, err := Interpolate(`SELECT ?, ?;`, []any{1})
+
+		assert.ErrorIs(t, err, ErrMissingArgument)
+	})
+
+	t.Run("unsupported argument", func(t *testing.T) {
+		_, err := Interpolate(`SELECT ?;`, []any{struct{}{}})
+
+		assert.ErrorIs(t, err, ErrUnsupportedArgument)
+	})
+
+	t.Run("time format", func(t *testing.T) {
+		defer func(f string) { TimeFormat = f }(TimeFormat)
+		TimeFormat = SQLiteTimeFormat
+
+		query, err := Interpolate(`SELECT ?;`, []any{time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC)})
+
+		require.NoError(t, err)
+		assert.Equal(t, `SELECT '2023-04-05 06:07:08+00:00';`, query)
+	})
+}
+
+func TestLiteral(t *testing.T) {
+	literal, err := Literal(-1)
+
+	require.NoError(t, err)
+	assert.Equal(t, "-1", literal)
+
+	_, err = Literal(map[string]any{})
+
+	assert.ErrorIs(t, err, ErrUnsupportedArgument)
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		`SELECT * FROM "T" WHERE "ID" = $v1;`:                            `SELECT * FROM "T" WHERE "ID" = ?`,
+		`SELECT * FROM "T" WHERE "ID" IN (?1, ?2, ?3);`:                  `SELECT * FROM "T" WHERE "ID" IN (?)`,
+		"SELECT  *\n FROM \"T\" -- comment\n WHERE \"ID\" = :id /* x */": `SELECT * FROM "T" WHERE "ID" = ?`,
+		`INSERT INTO "T" ("A", "B") VALUES (?, ?), (?, ?);`:              `INSERT INTO "T" ("A", "B") VALUES (?)`,
+		`SELECT 'it''s', X'00ff', 1.5e-3, -2 FROM "a ""b"" c"`:           `SELECT ?, -? FROM "a ""b"" c"`,
+		`SELECT "Count" + 1, v1, [x 1] FROM "T1"`:                        `SELECT "Count" + ?, v1, [x 1] FROM "T1"`,
+	}
+
+	for query, expected := range tests {
+		t.Run(query, func(t *testing.T) {
+			assert.Equal(t, expected, Normalize(query))
+		})
+	}
+}
+
+type queryGenerator string
+
+func (g queryGenerator) Generate() (string, []any, error) {
+	return string(g), nil, nil
+}
+
+func TestFingerprint(t *testing.T) {
+	a, err := Fingerprint(queryGenerator(`SELECT * FROM "T" WHERE "ID" IN ($v1, $v2);`))
+	require.NoError(t, err)
+
+	b, err := Fingerprint(queryGenerator("SELECT * FROM \"T\"\n WHERE \"ID\" IN (?, ?) -- comment"))
+	require.NoError(t, err)
+
+	c, err := Fingerprint(queryGenerator(`SELECT * FROM "T" WHERE "Name" IN (?, ?)`))
+	require.NoError(t, err)
+
+	assert.Len(t, a, 32)
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+
+	t.Run("keeps literals and argument counts", func(t *testing.T) {
+		differences := [][2]string{
+			{`SELECT * FROM "T" LIMIT 1;`, `SELECT * FROM "T" LIMIT 10;`},
+			{`SELECT * FROM "T" WHERE "Name" = 'a';`, `SELECT * FROM "T" WHERE "Name" = 'b';`},
+			{`SELECT * FROM "T" WHERE "Data" = X'00';`, `SELECT * FROM "T" WHERE "Data" = X'01';`},
+			{`SELECT substr("Name", $v1, $v2) FROM "T";`, `SELECT substr("Name", $v1) FROM "T";`},
+			{`SELECT * FROM "T" WHERE "ID" IN ($v1, $v2);`, `SELECT * FROM "T" WHERE "ID" IN ($v1);`},
+		}
+
+		for _, queries := range differences {
+			a, err := Fingerprint(queryGenerator(queries[0]))
+			require.NoError(t, err)
+
+			b, err := Fingerprint(queryGenerator(queries[1]))
+			require.NoError(t, err)
+
+			assert.NotEqual(t, a, b, "%s and %s shouldn't share a fingerprint", queries[0], queries[1])
+		}
+	})
+}
+
+func TestInvalid(t *testing.T) {
+	assert.Equal(t, "!(failed)", Invalid(errors.New("failed")))
+}
@@ -0,0 +1,254 @@
+package generator
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	// ErrMissingArgument is returned by Interpolate when a placeholder doesn't have a matching argument.
+	ErrMissingArgument = errors.New("generator: missing argument for placeholder")
+
+	// ErrUnsupportedArgument is returned by Interpolate when an argument can't be written as a SQL literal.
+	ErrUnsupportedArgument = errors.New("generator: unsupported argument type")
+)
+
+// TimeFormat is the layout a time.Time is written with as a literal.
+//
+// When it's empty, the default, a time is written as time.Time.String, the same
+// text modernc.org/sqlite binds a time argument as. If the connection's DSN
+// sets "_time_format=sqlite" it should be set to SQLiteTimeFormat, so a literal
+// matches the value the driver would store.
+//
+// It should only be changed before any statements are generated.
+var TimeFormat = ""
+
+// SQLiteTimeFormat is the layout modernc.org/sqlite writes times with when the
+// DSN sets "_time_format=sqlite".
+const SQLiteTimeFormat = "2006-01-02 15:04:05.999999999-07:00"
+
+// Interpolate replaces the placeholders in the query with the arguments
+// written as SQL literals, so the query can be run as-is in the sqlite3 shell.
+//
+// Named ("$v1", ":v1", "@v1"), positional ("?") and numbered ("?1")
+// placeholders are supported. A named placeholder uses the sql.NamedArg with the
+// same name, or the argument at its index if there isn't one. Placeholders in
+// string literals, quoted identifiers and comments are left alone.
+//
+// It's intended for logging and debugging, the query should always be run
+// with its arguments bound.
+func Interpolate(query string, args []any) (string, error) {
+	named := make(map[string]any)
+	for _, arg := range args {
+		if n, ok := arg.(sql.NamedArg); ok {
+			named[n.Name] = n.Value
+		}
+	}
+
+	positional := func(i int) (any, error) {
+		if i < 1 || i > len(args) {
+			return nil, fmt.Errorf("%w: ?%d", ErrMissingArgument, i)
+		}
+		if n, ok := args[i-1].(sql.NamedArg); ok {
+			return n.Value, nil
+		}
+		return args[i-1], nil
+	}
+
+	var out strings.Builder
+	var index int
+	indexes := make(map[string]int)
+
+	runes := []rune(query)
+	copyUntil := func(i int, end string) int {
+		e := []rune(end)
+		for ; i+len(e) <= len(runes); i++ {
+			if string(runes[i:i+len(e)]) == end {
+				_, _ = out.WriteString(string(runes[i : i+len(e)]))
+				return i + len(e)
+			}
+			_, _ = out.WriteRune(runes[i])
+		}
+		_, _ = out.WriteString(string(runes[i:]))
+		return len(runes)
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			_, _ = out.WriteString("--")
+			i = copyUntil(i+2, "\n")
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			_, _ = out.WriteString("/*")
+			i = copyUntil(i+2, "*/")
+		case r == '\'' || r == '"' || r == '`':
+			_, _ = out.WriteRune(r)
+			i = copyUntil(i+1, string(r))
+		case r == '[':
+			_, _ = out.WriteRune(r)
+			i = copyUntil(i+1, "]")
+		case r == '?':
+			start := i + 1
+			for i = start; i < len(runes) && unicode.IsDigit(runes[i]); i++ {
+			}
+			n := index + 1
+			if i > start {
+				n, _ = strconv.Atoi(string(runes[start:i]))
+			}
+			index = max(index, n)
+
+			value, err := positional(n)
+			if err != nil {
+				return "", err
+			}
+			if err := writeLiteral(&out, value); err != nil {
+				return "", err
+			}
+		case (r == '$' || r == ':' || r == '@') && i+1 < len(runes) && isParameterRune(runes[i+1]):
+			start := i + 1
+			for i = start; i < len(runes) && isParameterRune(runes[i]); i++ {
+			}
+			name := string(runes[start:i])
+
+			value, ok := named[name]
+			if !ok {
+				n, seen := indexes[name]
+				if !seen {
+					index++
+					n = index
+					indexes[name] = n
+				}
+
+				var err error
+				if value, err = positional(n); err != nil {
+					return "", fmt.Errorf("%w: %c%s", ErrMissingArgument, r, name)
+				}
+			}
+			if err := writeLiteral(&out, value); err != nil {
+				return "", err
+			}
+		default:
+			_, _ = out.WriteRune(r)
+			i++
+		}
+	}
+
+	return out.String(), nil
+}
+
+// Literal returns the value written as a SQL literal, using the same rules as
+// Interpolate.
+func Literal(value any) (string, error) {
+	var out strings.Builder
+	if err := writeLiteral(&out, value); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func isParameterRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// writeLiteral writes the value as a SQL literal.
+func writeLiteral(out *strings.Builder, value any) error {
+	if v, ok := value.(driver.Valuer); ok {
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+			value = nil
+		} else {
+			var err error
+			if value, err = v.Value(); err != nil {
+				return err
+			}
+		}
+	}
+
+	switch v := value.(type) {
+	case nil:
+		_, _ = out.WriteString("NULL")
+		return nil
+	case string:
+		writeString(out, v)
+		return nil
+	case []byte:
+		if v == nil {
+			_, _ = out.WriteString("NULL")
+		} else {
+			_, _ = out.WriteString("X'" + strings.ToUpper(hex.EncodeToString(v)) + "'")
+		}
+		return nil
+	case bool:
+		if v {
+			_, _ = out.WriteString("1")
+		} else {
+			_, _ = out.WriteString("0")
+		}
+		return nil
+	case time.Time:
+		if TimeFormat == "" {
+			writeString(out, v.String())
+		} else {
+			writeString(out, v.Format(TimeFormat))
+		}
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			_, _ = out.WriteString("NULL")
+			return nil
+		}
+		return writeLiteral(out, rv.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		_, _ = out.WriteString(strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		_, _ = out.WriteString(strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		switch {
+		case math.IsNaN(f):
+			_, _ = out.WriteString("NULL")
+		case math.IsInf(f, 1):
+			_, _ = out.WriteString("1e999")
+		case math.IsInf(f, -1):
+			_, _ = out.WriteString("-1e999")
+		default:
+			s := strconv.FormatFloat(f, 'g', -1, 64)
+			if !strings.ContainsAny(s, ".eE") {
+				s += ".0"
+			}
+			_, _ = out.WriteString(s)
+		}
+	case reflect.String:
+		writeString(out, rv.String())
+	case reflect.Bool:
+		return writeLiteral(out, rv.Bool())
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedArgument, value)
+	}
+
+	return nil
+}
+
+// writeString writes a quoted string literal. A string that contains a NUL
+// byte is written as a blob cast to text, as SQLite ends a literal at the NUL.
+func writeString(out *strings.Builder, s string) {
+	if strings.ContainsRune(s, 0) {
+		_, _ = out.WriteString("CAST(X'" + strings.ToUpper(hex.EncodeToString([]byte(s))) + "' AS TEXT)")
+		return
+	}
+
+	_, _ = out.WriteString("'" + strings.ReplaceAll(s, "'", "''") + "'")
+}
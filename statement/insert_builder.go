@@ -1,8 +1,7 @@
 package statement
 
 import (
-	"database/sql"
-	"sort"
+	"errors"
 	"strings"
 
 	"github.com/maddiesch/go-raptor/statement/dialect"
@@ -10,6 +9,10 @@ import (
 	"github.com/maddiesch/go-raptor/statement/query"
 )
 
+// ErrInvalidInsert is the error of a BuildError for an insert that combines
+// parts that can't be used together, such as values and a select.
+var ErrInvalidInsert = errors.New("statement: invalid insert")
+
 type InsertValue struct {
 	ColumnName string
 	Value      any
@@ -20,6 +23,12 @@ type InsertBuilder struct {
 	orReplace bool
 	orIgnore  bool
 	values    map[string]any
+	rows      []map[string]any
+	hasRows   bool     // Set by Rows and Objects, even when they're given no rows
+	columns   []string // The inserted columns when set by FromSelect, or on chunks so every chunk inserts the same columns
+	query     *SelectBuilder
+	conflicts []*conflictClause
+	err       error
 }
 
 func Insert() *InsertBuilder {
@@ -62,7 +71,35 @@ func (b *InsertBuilder) Value(column string, value any) *InsertBuilder {
 	return b
 }
 
+// FromSelect inserts the rows returned by the select into the columns. If no
+// columns are given, every column of the table is inserted in order.
+//
+// An insert from a select can't also have values, rows or objects.
+func (b *InsertBuilder) FromSelect(columns []string, sel *SelectBuilder) *InsertBuilder {
+	b.columns = columns
+	b.query = sel
+
+	return b
+}
+
 func (b *InsertBuilder) Generate() (string, []any, error) {
+	q, args, err := b.GenerateFragment(generator.NewArgumentNameProvider(generator.DefaultParameterStyle))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return q + ";", args, nil
+}
+
+// GenerateFragment generates the insert statement for use inside another statement.
+func (b *InsertBuilder) GenerateFragment(provider generator.ArgumentNameProvider) (string, []any, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+	if b.tableName == "" {
+		return "", nil, missing("INSERT", "table")
+	}
+
 	var query query.Builder
 	var args []any
 
@@ -75,27 +112,83 @@ func (b *InsertBuilder) Generate() (string, []any, error) {
 	}
 	_, _ = query.WriteStringf("INTO %s ", dialect.Identifier(b.tableName))
 
-	provider := generator.NewIncrementingArgumentNameProvider()
-
-	if len(b.values) == 0 {
+	rows, columns, err := b.allRows()
+	if err != nil {
+		return "", nil, err
+	}
+	if b.hasRows && len(rows) == 0 && b.query == nil {
+		return "", nil, missing("INSERT", "rows")
+	}
+	if b.query != nil {
+		sel, sArgs, err := b.generateSelect(provider)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(columns) > 0 {
+			quoted := make([]string, len(columns))
+			for i, column := range columns {
+				quoted[i] = dialect.Identifier(column)
+			}
+			_, _ = query.WriteStringf("(%s) ", strings.Join(quoted, ", "))
+		}
+		_, _ = query.WriteString(sel)
+		args = append(args, sArgs...)
+	} else if len(columns) == 0 {
+		if len(b.conflicts) > 0 {
+			return "", nil, missing("INSERT", "values for the ON CONFLICT clause")
+		}
 		_, _ = query.WriteString("DEFAULT VALUES")
 	} else {
-		var columns, values []string
+		quoted := make([]string, len(columns))
+		for i, column := range columns {
+			quoted[i] = dialect.Identifier(column)
+		}
+
+		tuples := make([]string, len(rows))
+		for i, row := range rows {
+			values := make([]string, len(columns))
+			for j, column := range columns {
+				placeholder, arg := generator.Bind(provider, row[column])
+				values[j] = placeholder
+				args = append(args, arg)
+			}
+			tuples[i] = "(" + strings.Join(values, ", ") + ")"
+		}
 
-		sortedColumns := keys(b.values)
-		sort.Strings(sortedColumns)
+		_, _ = query.WriteStringf("(%s) VALUES %s", strings.Join(quoted, ", "), strings.Join(tuples, ", "))
+	}
 
-		for _, column := range sortedColumns {
-			vName := provider.Next()
-			columns = append(columns, dialect.Identifier(column))
-			values = append(values, "$"+vName)
-			args = append(args, sql.Named(vName, b.values[column]))
+	for i, c := range b.conflicts {
+		if len(c.columns) == 0 && i < len(b.conflicts)-1 {
+			return "", nil, buildError("INSERT", ErrInvalidInsert, "only the last ON CONFLICT clause can omit its conflict target")
+		}
+		clause, cArgs, err := c.generate(provider)
+		if err != nil {
+			return "", nil, err
 		}
+		_, _ = query.WriteString(" " + clause)
+		args = append(args, cArgs...)
+	}
 
-		_, _ = query.WriteStringf("(%s) VALUES (%s)", strings.Join(columns, ", "), strings.Join(values, ", "))
+	return query.Builder.String(), args, nil
+}
+
+// generateSelect generates the select of an INSERT ... SELECT statement.
+//
+// SQLite can't tell an upsert's ON CONFLICT apart from a join constraint unless
+// the select has a WHERE clause, so when there are conflict clauses a
+// select without one is wrapped in a subquery that has a WHERE clause.
+func (b *InsertBuilder) generateSelect(provider generator.ArgumentNameProvider) (string, []any, error) {
+	sel, args, err := b.query.GenerateFragment(provider)
+	if err != nil {
+		return "", nil, err
 	}
 
-	return query.String(), args, nil
+	if len(b.conflicts) > 0 && (b.query.where == nil || len(b.query.compound) > 0) {
+		sel = "SELECT * FROM (" + sel + ") WHERE true"
+	}
+
+	return sel, args, nil
 }
 
 func (b *InsertBuilder) Returning(col ...string) *InsertReturnBuilder {
@@ -111,28 +204,29 @@ type InsertReturnBuilder struct {
 }
 
 func (b *InsertReturnBuilder) Generate() (string, []any, error) {
-	q, args, err := b.Insert.Generate()
+	q, args, err := b.GenerateFragment(generator.NewArgumentNameProvider(generator.DefaultParameterStyle))
 	if err != nil {
 		return "", nil, err
 	}
 
-	var columns []string
-	for _, c := range b.Columns {
-		columns = append(columns, dialect.Identifier(c))
-	}
-	if len(columns) == 0 {
-		columns = append(columns, "*")
-	}
-
-	return q[:len(q)-1] + " RETURNING " + strings.Join(columns, ", ") + ";", args, nil
+	return q + ";", args, nil
 }
 
-func keys[K comparable, V any](in map[K]V) []K {
-	result := make([]K, 0, len(in))
-
-	for k := range in {
-		result = append(result, k)
+// GenerateFragment generates the insert statement for use inside another statement.
+func (b *InsertReturnBuilder) GenerateFragment(provider generator.ArgumentNameProvider) (string, []any, error) {
+	q, args, err := b.Insert.GenerateFragment(provider)
+	if err != nil {
+		return "", nil, err
 	}
 
-	return result
+	returning := &returningClause{values: returningColumns(b.Columns...)}
+
+	return q + returning.generate(), args, nil
 }
+
+var (
+	_ generator.Generator = (*InsertBuilder)(nil)
+	_ generator.Fragment  = (*InsertBuilder)(nil)
+	_ generator.Generator = (*InsertReturnBuilder)(nil)
+	_ generator.Fragment  = (*InsertReturnBuilder)(nil)
+)
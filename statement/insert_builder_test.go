@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/maddiesch/go-raptor/statement"
+	"github.com/maddiesch/go-raptor/statement/conditional"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -62,6 +63,160 @@ func TestInsertBuilder(t *testing.T) {
 		assert.Len(t, args, 0)
 	})
 
+	t.Run("multiple rows", func(t *testing.T) {
+		query, args, err := statement.Insert().Into("TestTable").Rows([]map[string]any{
+			{"Name": "MTG", "Age": 30},
+			{"Name": "Elle", "Age": nil},
+		}).Generate()
+
+		require.NoError(t, err)
+
+		assert.Equal(t, `INSERT INTO "TestTable" ("Age", "Name") VALUES ($v1, $v2), ($v3, $v4);`, query)
+		assert.Equal(t, []any{sql.Named("v1", 30), sql.Named("v2", "MTG"), sql.Named("v3", nil), sql.Named("v4", "Elle")}, args)
+	})
+
+	t.Run("rows with different columns", func(t *testing.T) {
+		_, _, err := statement.Insert().Into("TestTable").Rows([]map[string]any{
+			{"Name": "MTG", "Age": 30},
+			{"Name": "Elle", "Type": "Dog"},
+		}).Generate()
+
+		var buildErr *statement.BuildError
+		require.ErrorAs(t, err, &buildErr)
+		assert.ErrorIs(t, err, statement.ErrInvalidRows)
+		assert.Equal(t, `row 1 is missing column "Age"`, buildErr.Reason)
+
+		_, err = statement.Insert().Into("TestTable").Value("Name", "MTG").Rows([]map[string]any{{"Name": "Elle", "Age": 30}}).Chunks()
+		assert.ErrorIs(t, err, statement.ErrInvalidRows)
+	})
+
+	t.Run("no rows", func(t *testing.T) {
+		_, _, err := statement.Insert().Into("TestTable").Rows([]map[string]any{}).Generate()
+		assert.ErrorIs(t, err, statement.ErrIncompleteStatement)
+
+		chunks, err := statement.Insert().Into("TestTable").Objects([]struct{ Name string }{}).Chunks()
+		require.NoError(t, err)
+		assert.Empty(t, chunks)
+	})
+
+	t.Run("invalid rows", func(t *testing.T) {
+		_, _, err := statement.Insert().Into("TestTable").Rows([]string{"MTG"}).Generate()
+
+		assert.ErrorIs(t, err, statement.ErrInvalidRows)
+	})
+
+	t.Run("objects", func(t *testing.T) {
+		type person struct {
+			Name    string
+			Age     int    `db:"PersonAge"`
+			Ignored string `db:"-"`
+			private string
+		}
+
+		query, args, err := statement.Insert().Into("TestTable").Objects([]*person{
+			{Name: "MTG", Age: 30},
+			{Name: "Elle", Age: 25},
+		}).Generate()
+
+		require.NoError(t, err)
+
+		assert.Equal(t, `INSERT INTO "TestTable" ("Name", "PersonAge") VALUES ($v1, $v2), ($v3, $v4);`, query)
+		assert.Equal(t, []any{sql.Named("v1", "MTG"), sql.Named("v2", 30), sql.Named("v3", "Elle"), sql.Named("v4", 25)}, args)
+	})
+
+	t.Run("chunks", func(t *testing.T) {
+		defer func(n int) { statement.MaxVariableNumber = n }(statement.MaxVariableNumber)
+		statement.MaxVariableNumber = 5
+
+		rows := make([]map[string]any, 5)
+		for i := range rows {
+			rows[i] = map[string]any{"Name": "MTG", "Age": i}
+		}
+
+		chunks, err := statement.Insert().Into("TestTable").Rows(rows).OnConflict().DoNothing().Chunks()
+
+		require.NoError(t, err)
+		require.Len(t, chunks, 3)
+
+		query, args, err := chunks[2].Generate()
+
+		require.NoError(t, err)
+
+		assert.Equal(t, `INSERT INTO "TestTable" ("Age", "Name") VALUES ($v1, $v2) ON CONFLICT DO NOTHING;`, query)
+		assert.Equal(t, []any{sql.Named("v1", 4), sql.Named("v2", "MTG")}, args)
+	})
+
+	t.Run("from select", func(t *testing.T) {
+		sel := statement.Select("Name", "Age").From("People").Where(conditional.GreaterThan("Age", 30))
+
+		query, args, err := statement.Insert().Into("Archive").FromSelect([]string{"Name", "Age"}, sel).Generate()
+
+		require.NoError(t, err)
+
+		assert.Equal(t, `INSERT INTO "Archive" ("Name", "Age") SELECT "Name", "Age" FROM "People" WHERE "Age" > $v1;`, query)
+		assert.Equal(t, []any{sql.Named("v1", 30)}, args)
+	})
+
+	t.Run("from select with values", func(t *testing.T) {
+		sel := statement.Select("Name").From("People")
+
+		_, _, err := statement.Insert().Into("Archive").Value("Age", 30).FromSelect(nil, sel).Generate()
+		assert.ErrorIs(t, err, statement.ErrInvalidInsert)
+
+		_, err = statement.Insert().Into("Archive").FromSelect([]string{"Name"}, sel).Rows([]map[string]any{}).Chunks()
+		assert.ErrorIs(t, err, statement.ErrInvalidInsert)
+	})
+
+	t.Run("from select on conflict", func(t *testing.T) {
+		sel := statement.Select("Name").From("People")
+
+		query, _, err := statement.Insert().Into("Archive").FromSelect(nil, sel).OnConflict().DoNothing().Generate()
+
+		require.NoError(t, err)
+
+		assert.Equal(t, `INSERT INTO "Archive" SELECT * FROM (SELECT "Name" FROM "People") WHERE true ON CONFLICT DO NOTHING;`, query)
+	})
+
+	t.Run("on conflict", func(t *testing.T) {
+		t.Run("do nothing", func(t *testing.T) {
+			query, _, err := statement.Insert().Into("TestTable").Value("Name", "MTG").OnConflict("Name").DoNothing().Generate()
+
+			require.NoError(t, err)
+
+			assert.Equal(t, `INSERT INTO "TestTable" ("Name") VALUES ($v1) ON CONFLICT ("Name") DO NOTHING;`, query)
+		})
+
+		t.Run("do nothing on any conflict", func(t *testing.T) {
+			query, _, err := statement.Insert().Into("TestTable").Value("Name", "MTG").OnConflict().DoNothing().Generate()
+
+			require.NoError(t, err)
+
+			assert.Equal(t, `INSERT INTO "TestTable" ("Name") VALUES ($v1) ON CONFLICT DO NOTHING;`, query)
+		})
+
+		t.Run("do update", func(t *testing.T) {
+			query, args, err := statement.Insert().Into("TestTable").Value("Name", "MTG").Value("Age", 30).
+				OnConflict("Name").
+				DoUpdateSetExcluded("Age").
+				DoUpdateSet(statement.UpdateValue{ColumnName: "Note", Value: "updated"}).
+				Where(conditional.LessThan("Age", 100)).
+				Generate()
+
+			require.NoError(t, err)
+
+			assert.Equal(t, `INSERT INTO "TestTable" ("Age", "Name") VALUES ($v1, $v2) ON CONFLICT ("Name") DO UPDATE SET "Age" = excluded."Age", "Note" = $v3 WHERE "Age" < $v4;`, query)
+			assert.Equal(t, []any{sql.Named("v1", 30), sql.Named("v2", "MTG"), sql.Named("v3", "updated"), sql.Named("v4", 100)}, args)
+		})
+
+		t.Run("returning", func(t *testing.T) {
+			query, _, err := statement.Insert().Into("TestTable").Value("Name", "MTG").OnConflict("Name").DoUpdateSetExcluded("Name").Returning("ID").Generate()
+
+			require.NoError(t, err)
+
+			assert.Equal(t, `INSERT INTO "TestTable" ("Name") VALUES ($v1) ON CONFLICT ("Name") DO UPDATE SET "Name" = excluded."Name" RETURNING "ID";`, query)
+		})
+	})
+
 	t.Run("returning", func(t *testing.T) {
 		t.Run("single column", func(t *testing.T) {
 			query, _, err := statement.Insert().Into("TestTable").Value("Name", "MTG").Value("Age", 30).Returning("Name").Generate()
@@ -0,0 +1,199 @@
+package statement
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/maddiesch/go-raptor/statement/generator"
+)
+
+var (
+	// ErrInvalidRows is the error of a BuildError when Rows is given anything other than a slice of maps with string keys.
+	ErrInvalidRows = errors.New("statement: rows must be a slice of maps with string keys")
+
+	// ErrInvalidObjects is the error of a BuildError when Objects is given anything other than a slice of structs.
+	ErrInvalidObjects = errors.New("statement: objects must be a slice of structs")
+)
+
+// MaxVariableNumber is the maximum number of arguments a chunk of a multi-row
+// insert binds. It matches SQLite's default SQLITE_MAX_VARIABLE_NUMBER.
+var MaxVariableNumber = 32766
+
+// Rows adds a row to the insert for each map in rows, which must be a slice of
+// maps with string keys such as []raptor.Record.
+//
+// Every row must have the same keys, which are the columns of the insert, as
+// SQLite can't insert the default value of a column missing from one row.
+// Values set with Value or ValueMap are inserted as the first row.
+//
+// An insert given no rows fails to generate, and has no chunks.
+func (b *InsertBuilder) Rows(rows any) *InsertBuilder {
+	b.hasRows = true
+
+	rv := reflect.ValueOf(rows)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		b.err = buildError("INSERT", ErrInvalidRows, "rows can't be a %T", rows)
+		return b
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		row := reflect.Indirect(rv.Index(i))
+		if row.Kind() == reflect.Interface {
+			row = reflect.Indirect(row.Elem())
+		}
+		if row.Kind() != reflect.Map || row.Type().Key().Kind() != reflect.String {
+			b.err = buildError("INSERT", ErrInvalidRows, "row %d can't be a %T", i, rv.Index(i).Interface())
+			return b
+		}
+
+		values := make(map[string]any, row.Len())
+		iter := row.MapRange()
+		for iter.Next() {
+			values[iter.Key().String()] = iter.Value().Interface()
+		}
+		b.rows = append(b.rows, values)
+	}
+
+	return b
+}
+
+// Objects adds a row to the insert for each struct in objects, which must be
+// a slice of structs or pointers to structs.
+//
+// Exported fields are inserted using the column name from their db tag, or the
+// field name if there is no tag. Fields tagged with "-" are skipped.
+func (b *InsertBuilder) Objects(objects any) *InsertBuilder {
+	b.hasRows = true
+
+	rv := reflect.ValueOf(objects)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		b.err = buildError("INSERT", ErrInvalidObjects, "objects can't be a %T", objects)
+		return b
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		obj := reflect.Indirect(rv.Index(i))
+		if obj.Kind() == reflect.Interface {
+			obj = reflect.Indirect(obj.Elem())
+		}
+		if obj.Kind() != reflect.Struct {
+			b.err = buildError("INSERT", ErrInvalidObjects, "object %d can't be a %T", i, rv.Index(i).Interface())
+			return b
+		}
+
+		b.rows = append(b.rows, objectValues(obj))
+	}
+
+	return b
+}
+
+func objectValues(obj reflect.Value) map[string]any {
+	values := make(map[string]any)
+
+	t := obj.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("db"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+
+		values[name] = obj.Field(i).Interface()
+	}
+
+	return values
+}
+
+// allRows returns every row of the insert, and their sorted columns.
+func (b *InsertBuilder) allRows() ([]map[string]any, []string, error) {
+	if b.query != nil && (len(b.values) > 0 || b.hasRows) {
+		return nil, nil, buildError("INSERT", ErrInvalidInsert, "values can't be inserted with the rows of a select")
+	}
+
+	rows := b.rows
+	if len(b.values) > 0 {
+		rows = append([]map[string]any{b.values}, rows...)
+	}
+
+	if b.columns != nil || len(rows) == 0 {
+		return rows, b.columns, nil
+	}
+
+	columns := make([]string, 0, len(rows[0]))
+	for column := range rows[0] {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	for i, row := range rows[1:] {
+		if len(row) != len(columns) {
+			return nil, nil, buildError("INSERT", ErrInvalidRows, "row %d has %d columns, the first row has %d", i+1, len(row), len(columns))
+		}
+		for _, column := range columns {
+			if _, ok := row[column]; !ok {
+				return nil, nil, buildError("INSERT", ErrInvalidRows, "row %d is missing column %q", i+1, column)
+			}
+		}
+	}
+
+	return rows, columns, nil
+}
+
+// Chunks splits the insert into statements that each bind no more than
+// MaxVariableNumber arguments.
+//
+// Every chunk inserts the same columns, and has the same conflict clauses as
+// the original insert. An insert that doesn't need to be split, or inserts the
+// rows of a select, is returned as the only chunk. An insert given no rows has
+// no chunks.
+func (b *InsertBuilder) Chunks() ([]*InsertBuilder, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	rows, columns, err := b.allRows()
+	if err != nil {
+		return nil, err
+	}
+	if b.hasRows && len(rows) == 0 {
+		return nil, nil
+	}
+	if len(columns) == 0 || b.query != nil {
+		return []*InsertBuilder{b}, nil
+	}
+
+	var reserved int
+	provider := generator.NewIncrementingArgumentNameProvider()
+	for _, c := range b.conflicts {
+		_, args, err := c.generate(provider)
+		if err != nil {
+			return nil, err
+		}
+		reserved += len(args)
+	}
+
+	size := max(1, (MaxVariableNumber-reserved)/len(columns))
+	if len(rows) <= size {
+		return []*InsertBuilder{b}, nil
+	}
+
+	var chunks []*InsertBuilder
+	for start := 0; start < len(rows); start += size {
+		chunk := *b
+		chunk.values = make(map[string]any)
+		chunk.rows = rows[start:min(start+size, len(rows))]
+		chunk.columns = columns
+		chunks = append(chunks, &chunk)
+	}
+
+	return chunks, nil
+}
@@ -0,0 +1,57 @@
+package statement
+
+import (
+	"errors"
+
+	"github.com/maddiesch/go-raptor/statement/conditional"
+	"github.com/maddiesch/go-raptor/statement/generator"
+)
+
+// ErrInvalidCondition is the error of a BuildError for a condition or
+// expression of a clause that failed to generate, such as a conditional that
+// wraps a nil conditional. The error it returned is the Cause.
+var ErrInvalidCondition = errors.New("statement: invalid condition")
+
+// generateCondition generates the condition of the clause, returning an error
+// as a BuildError for the clause.
+func generateCondition(clause string, c conditional.Conditional, provider generator.ArgumentNameProvider) (string, []any, error) {
+	q, args, err := conditional.Generate(c, provider)
+	if err != nil {
+		return "", nil, conditionError(clause, err)
+	}
+
+	return q, args, nil
+}
+
+// conditionError returns the error as a BuildError for the clause, unless it
+// already is one, e.g. from a subquery.
+func conditionError(clause string, err error) error {
+	var buildErr *BuildError
+	if errors.As(err, &buildErr) {
+		return err
+	}
+
+	return buildError(clause, ErrInvalidCondition, "%v", err).causedBy(err)
+}
+
+// generateLiteral generates the conditional of the clause with its arguments
+// written as SQL literals.
+//
+// SQLite doesn't allow bound parameters in a schema, such as a table's CHECK
+// constraints, defaults and generated columns, or the WHERE clause of a partial
+// index, as the schema is stored as the text of the statement.
+//
+// An Expression can be passed as it has the same method set as a conditional.
+func generateLiteral(clause string, c conditional.Conditional) (string, error) {
+	q, args, err := generateCondition(clause, c, generator.NewIncrementingArgumentNameProvider())
+	if err != nil {
+		return "", err
+	}
+
+	literal, err := generator.Interpolate(q, args)
+	if err != nil {
+		return "", conditionError(clause, err)
+	}
+
+	return literal, nil
+}
@@ -0,0 +1,54 @@
+package statement
+
+import (
+	"strings"
+
+	"github.com/maddiesch/go-raptor/statement/dialect"
+)
+
+// returningClause is the RETURNING clause shared by the insert, update and
+// delete builders.
+type returningClause struct {
+	values []UpdateReturnValue
+}
+
+func returningColumns(c ...string) []UpdateReturnValue {
+	values := make([]UpdateReturnValue, len(c))
+	for i, col := range c {
+		values[i] = UpdateReturnValue{ColumnName: col}
+	}
+	return values
+}
+
+// add appends the values to the clause, creating it if needed.
+func (r *returningClause) add(values ...UpdateReturnValue) *returningClause {
+	if r == nil {
+		r = &returningClause{}
+	}
+	r.values = append(r.values, values...)
+	return r
+}
+
+// generate returns the clause with a leading space, or an empty string if
+// there is no clause. A clause without values returns every column.
+//
+// A value with an alias is an expression, so its column name isn't quoted.
+func (r *returningClause) generate() string {
+	if r == nil {
+		return ""
+	}
+
+	columns := make([]string, len(r.values))
+	for i, v := range r.values {
+		if v.ColumnAlias != "" {
+			columns[i] = v.ColumnName + " AS " + dialect.Identifier(v.ColumnAlias)
+		} else {
+			columns[i] = dialect.Column(v.ColumnName)
+		}
+	}
+	if len(columns) == 0 {
+		columns = append(columns, "*")
+	}
+
+	return " RETURNING " + strings.Join(columns, ", ")
+}
@@ -1,7 +1,7 @@
 package statement
 
 import (
-	"fmt"
+	"errors"
 	"strings"
 
 	"github.com/maddiesch/go-raptor/statement/conditional"
@@ -11,40 +11,254 @@ import (
 )
 
 type SelectBuilder struct {
-	tableName  string
+	tables     []tableRef
 	isDistinct bool
-	columns    []string
+	columns    []Expression
 	where      conditional.Conditional
+	groupBy    []string
+	having     conditional.Conditional
+	compound   []compoundSelect
 	limit      *int64
 	offset     *int64
+	windows    []namedWindow
 	orderBy    []OrderBy
 }
 
+// compoundSelect is a select combined with the select it's part of, e.g. UNION ALL.
+type compoundSelect struct {
+	operator string
+	sel      *SelectBuilder
+}
+
+// OrderBy is a term of an ORDER BY clause.
 type OrderBy struct {
 	Column    string
 	Ascending bool
+	Expr      Expression // Orders by the expression instead of the Column when set
+	Collate   Collation  // Compares text using the collation instead of the default one
+	Nulls     NullsOrder
 }
 
+// NullsOrder places NULL values first or last, regardless of the direction.
+type NullsOrder uint8
+
+const (
+	// NullsDefault uses SQLite's default, NULL values are smaller than any other value.
+	NullsDefault NullsOrder = iota
+	NullsFirst
+	NullsLast
+)
+
+// errOrderByExpr is the error of the String of an OrderBy with an Expr.
+var errOrderByExpr = errors.New("statement: an ORDER BY expression can only be generated by its statement")
+
+// String returns the term of a Column.
+//
+// A term with an Expr can only be generated by the statement it's part of,
+// which binds its arguments, so its String is SQL that fails to prepare.
 func (o OrderBy) String() string {
-	key := "DESC"
+	if o.Expr != nil {
+		return generator.Invalid(errOrderByExpr)
+	}
+
+	term, _, _ := o.generate(generator.NewIncrementingArgumentNameProvider())
+	return term
+}
+
+func (o OrderBy) generate(provider generator.ArgumentNameProvider) (string, []any, error) {
+	var term string
+	var args []any
+	if o.Expr != nil {
+		var err error
+		if term, args, err = generateExpression(o.Expr, provider); err != nil {
+			return "", nil, err
+		}
+	} else {
+		term = dialect.Column(o.Column)
+	}
+
+	if o.Collate != "" {
+		term += " " + o.Collate.generate()
+	}
+
 	if o.Ascending {
-		key = "ASC"
+		term += " ASC"
+	} else {
+		term += " DESC"
 	}
-	return fmt.Sprintf("%s %s", dialect.Identifier(o.Column), key)
+
+	switch o.Nulls {
+	case NullsFirst:
+		term += " NULLS FIRST"
+	case NullsLast:
+		term += " NULLS LAST"
+	}
+
+	return term, args, nil
+}
+
+// generateOrderBy generates the terms of an ORDER BY clause.
+func generateOrderBy(terms []OrderBy, provider generator.ArgumentNameProvider) (string, []any, error) {
+	var args []any
+
+	order := make([]string, len(terms))
+	for i, o := range terms {
+		term, tArgs, err := o.generate(provider)
+		if err != nil {
+			return "", nil, err
+		}
+		order[i] = term
+		args = append(args, tArgs...)
+	}
+
+	return strings.Join(order, ", "), args, nil
+}
+
+// tableRef is a table in the FROM clause, every table after the first is joined.
+type tableRef struct {
+	join     string
+	name     string
+	subquery generator.Fragment
+	alias    string
+	on       conditional.Conditional
+	using    []string
+}
+
+func (t tableRef) generate(provider generator.ArgumentNameProvider) (string, []any, error) {
+	if t.subquery == nil && t.name == "" {
+		return "", nil, missing("FROM clause", "table name")
+	}
+
+	var query strings.Builder
+	var args []any
+
+	if t.join != "" {
+		_, _ = query.WriteString(t.join + " ")
+	}
+	if t.subquery != nil {
+		sub, sArgs, err := t.subquery.GenerateFragment(provider)
+		if err != nil {
+			return "", nil, err
+		}
+		_, _ = query.WriteString("(" + sub + ")")
+		args = append(args, sArgs...)
+	} else {
+		_, _ = query.WriteString(dialect.Identifier(t.name))
+	}
+	if t.alias != "" {
+		_, _ = query.WriteString(" AS " + dialect.Identifier(t.alias))
+	}
+
+	if t.on != nil {
+		on, oArgs, err := generateCondition("ON clause", t.on, provider)
+		if err != nil {
+			return "", nil, err
+		}
+		_, _ = query.WriteString(" ON " + on)
+		args = append(args, oArgs...)
+	} else if len(t.using) > 0 {
+		using := mapping(t.using, func(c string) string {
+			return dialect.Identifier(c)
+		})
+		_, _ = query.WriteString(" USING (" + strings.Join(using, ", ") + ")")
+	}
+
+	return query.String(), args, nil
 }
 
 func Select(columns ...string) *SelectBuilder {
 	return &SelectBuilder{
-		columns: columns,
+		columns: mapping(columns, ColumnRef),
 	}
 }
 
+// Columns adds expressions to the selected columns.
+func (b *SelectBuilder) Columns(expr ...Expression) *SelectBuilder {
+	b.columns = append(b.columns, expr...)
+
+	return b
+}
+
 func (b *SelectBuilder) From(table string) *SelectBuilder {
-	b.tableName = table
+	if len(b.tables) == 0 {
+		b.tables = append(b.tables, tableRef{name: table})
+	} else {
+		b.tables[0] = tableRef{name: table}
+	}
+
+	return b
+}
+
+// FromSelect selects from a subquery, use As to name the derived table.
+func (b *SelectBuilder) FromSelect(sel *SelectBuilder) *SelectBuilder {
+	ref := tableRef{subquery: sel}
+	if len(b.tables) == 0 {
+		b.tables = append(b.tables, ref)
+	} else {
+		b.tables[0] = ref
+	}
+
+	return b
+}
+
+// Join adds an inner join with the table.
+func (b *SelectBuilder) Join(table string) *SelectBuilder {
+	return b.join("JOIN", table)
+}
+
+// LeftJoin adds a left outer join with the table.
+func (b *SelectBuilder) LeftJoin(table string) *SelectBuilder {
+	return b.join("LEFT JOIN", table)
+}
+
+// CrossJoin adds a cross join with the table.
+func (b *SelectBuilder) CrossJoin(table string) *SelectBuilder {
+	return b.join("CROSS JOIN", table)
+}
+
+func (b *SelectBuilder) join(kind, table string) *SelectBuilder {
+	if len(b.tables) == 0 {
+		b.tables = append(b.tables, tableRef{})
+	}
+	b.tables = append(b.tables, tableRef{join: kind, name: table})
+
+	return b
+}
+
+// As sets the alias of the most recently added table.
+func (b *SelectBuilder) As(alias string) *SelectBuilder {
+	if t := b.lastTable(); t != nil {
+		t.alias = alias
+	}
+
+	return b
+}
+
+// On sets the join constraint of the most recently joined table.
+func (b *SelectBuilder) On(condition conditional.Conditional) *SelectBuilder {
+	if t := b.lastTable(); t != nil {
+		t.on = condition
+	}
+
+	return b
+}
+
+// Using sets the columns the most recently joined table is joined on.
+func (b *SelectBuilder) Using(columns ...string) *SelectBuilder {
+	if t := b.lastTable(); t != nil {
+		t.using = columns
+	}
 
 	return b
 }
 
+func (b *SelectBuilder) lastTable() *tableRef {
+	if len(b.tables) == 0 {
+		return nil
+	}
+	return &b.tables[len(b.tables)-1]
+}
+
 func (b *SelectBuilder) Distinct() *SelectBuilder {
 	b.isDistinct = true
 
@@ -57,6 +271,38 @@ func (b *SelectBuilder) Where(condition conditional.Conditional) *SelectBuilder
 	return b
 }
 
+func (b *SelectBuilder) GroupBy(columns ...string) *SelectBuilder {
+	b.groupBy = append(b.groupBy, columns...)
+
+	return b
+}
+
+func (b *SelectBuilder) Having(condition conditional.Conditional) *SelectBuilder {
+	b.having = condition
+
+	return b
+}
+
+// Union combines the rows of the select, removing duplicates.
+//
+// ORDER BY, LIMIT and OFFSET of the receiver apply to the combined rows, a
+// select with its own is wrapped in a subquery so they only apply to its rows.
+func (b *SelectBuilder) Union(sel *SelectBuilder) *SelectBuilder {
+	b.compound = append(b.compound, compoundSelect{"UNION", sel})
+
+	return b
+}
+
+// UnionAll combines the rows of the select, keeping duplicates.
+//
+// ORDER BY, LIMIT and OFFSET of the receiver apply to the combined rows, a
+// select with its own is wrapped in a subquery so they only apply to its rows.
+func (b *SelectBuilder) UnionAll(sel *SelectBuilder) *SelectBuilder {
+	b.compound = append(b.compound, compoundSelect{"UNION ALL", sel})
+
+	return b
+}
+
 func (b *SelectBuilder) Limit(l int64) *SelectBuilder {
 	b.limit = &l
 
@@ -70,15 +316,57 @@ func (b *SelectBuilder) Offset(o int64) *SelectBuilder {
 }
 
 func (b *SelectBuilder) OrderBy(col string, asc bool) *SelectBuilder {
-	b.orderBy = append(b.orderBy, OrderBy{
+	return b.OrderByTerms(OrderBy{
 		Column:    col,
 		Ascending: asc,
 	})
+}
+
+// OrderByExpr orders the rows by the expression, such as an aggregate.
+func (b *SelectBuilder) OrderByExpr(expr Expression, asc bool) *SelectBuilder {
+	return b.OrderByTerms(OrderBy{
+		Expr:      expr,
+		Ascending: asc,
+	})
+}
+
+// OrderByTerms orders the rows by the terms, e.g. to set the collation or the
+// placement of NULL values.
+func (b *SelectBuilder) OrderByTerms(o ...OrderBy) *SelectBuilder {
+	b.orderBy = append(b.orderBy, o...)
+
+	return b
+}
+
+// Window adds a named window to the WINDOW clause, which can be used by the
+// columns of the select with OverWindow.
+func (b *SelectBuilder) Window(name string, w *WindowBuilder) *SelectBuilder {
+	b.windows = append(b.windows, namedWindow{name, w})
 
 	return b
 }
 
 func (b *SelectBuilder) Generate() (string, []any, error) {
+	q, args, err := b.GenerateFragment(generator.NewArgumentNameProvider(generator.DefaultParameterStyle))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return q + ";", args, nil
+}
+
+// GenerateFragment generates the select statement for use as a subquery.
+//
+// A select must have a FROM clause or columns, and can only have an OFFSET
+// with a LIMIT.
+func (b *SelectBuilder) GenerateFragment(provider generator.ArgumentNameProvider) (string, []any, error) {
+	if len(b.tables) == 0 && len(b.columns) == 0 {
+		return "", nil, missing("SELECT", "FROM clause or columns")
+	}
+	if b.offset != nil && b.limit == nil {
+		return "", nil, missing("SELECT", "LIMIT for the OFFSET")
+	}
+
 	var query query.Builder
 	var args []any
 
@@ -91,18 +379,37 @@ func (b *SelectBuilder) Generate() (string, []any, error) {
 	if len(b.columns) == 0 {
 		_, _ = query.WriteRune('*')
 	} else {
-		col := mapping(b.columns, func(c string) string {
-			return dialect.Identifier(c)
-		})
-		_, _ = query.WriteString(strings.Join(col, ", "))
+		var columns []string
+		for _, c := range b.columns {
+			col, cArgs, err := generateExpression(c, provider)
+			if err != nil {
+				return "", nil, err
+			}
+			columns = append(columns, col)
+			args = append(args, cArgs...)
+		}
+		_, _ = query.WriteString(strings.Join(columns, ", "))
 	}
 
-	_, _ = query.WriteStringf(" FROM %s", dialect.Identifier(b.tableName))
-
-	provider := generator.NewIncrementingArgumentNameProvider()
+	for i, t := range b.tables {
+		table, tArgs, err := t.generate(provider)
+		if err != nil {
+			return "", nil, err
+		}
+		if i == 0 {
+			_, _ = query.WriteString(" FROM ")
+		} else {
+			_, _ = query.WriteRune(' ')
+		}
+		_, _ = query.WriteString(table)
+		args = append(args, tArgs...)
+	}
 
 	if b.where != nil {
-		where, wArgs := b.where.Generate(provider)
+		where, wArgs, err := generateCondition("WHERE clause", b.where, provider)
+		if err != nil {
+			return "", nil, err
+		}
 
 		_, _ = query.WriteString(" WHERE ")
 		_, _ = query.WriteString(where)
@@ -110,11 +417,60 @@ func (b *SelectBuilder) Generate() (string, []any, error) {
 		args = append(args, wArgs...)
 	}
 
+	if len(b.groupBy) > 0 {
+		group := mapping(b.groupBy, func(c string) string {
+			return dialect.Column(c)
+		})
+		_, _ = query.WriteStringf(" GROUP BY %s", strings.Join(group, ", "))
+	}
+
+	if b.having != nil {
+		having, hArgs, err := generateCondition("HAVING clause", b.having, provider)
+		if err != nil {
+			return "", nil, err
+		}
+
+		_, _ = query.WriteString(" HAVING ")
+		_, _ = query.WriteString(having)
+
+		args = append(args, hArgs...)
+	}
+
+	if len(b.windows) > 0 {
+		windows := make([]string, len(b.windows))
+		for i, w := range b.windows {
+			window, wArgs, err := w.window.generate(provider)
+			if err != nil {
+				return "", nil, err
+			}
+			windows[i] = dialect.Identifier(w.name) + " AS (" + window + ")"
+			args = append(args, wArgs...)
+		}
+		_, _ = query.WriteString(" WINDOW " + strings.Join(windows, ", "))
+	}
+
+	for _, c := range b.compound {
+		sub, cArgs, err := c.sel.GenerateFragment(provider)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if len(c.sel.orderBy) > 0 || c.sel.limit != nil || c.sel.offset != nil || len(c.sel.compound) > 0 {
+			sub = "SELECT * FROM (" + sub + ")"
+		}
+
+		_, _ = query.WriteStringf(" %s %s", c.operator, sub)
+
+		args = append(args, cArgs...)
+	}
+
 	if len(b.orderBy) > 0 {
-		order := strings.Join(mapping(b.orderBy, func(o OrderBy) string {
-			return o.String()
-		}), ", ")
-		_, _ = query.WriteStringf(" ORDER BY %s", order)
+		order, oArgs, err := generateOrderBy(b.orderBy, provider)
+		if err != nil {
+			return "", nil, err
+		}
+		_, _ = query.WriteString(" ORDER BY " + order)
+		args = append(args, oArgs...)
 	}
 
 	if b.limit != nil {
@@ -125,10 +481,13 @@ func (b *SelectBuilder) Generate() (string, []any, error) {
 		_, _ = query.WriteStringf(" OFFSET %d", *b.offset)
 	}
 
-	return query.String(), args, nil
+	return query.Builder.String(), args, nil
 }
 
-var _ generator.Generator = (*SelectBuilder)(nil)
+var (
+	_ generator.Generator = (*SelectBuilder)(nil)
+	_ generator.Fragment  = (*SelectBuilder)(nil)
+)
 
 func mapping[T any, R any](collection []T, fn func(item T) R) []R {
 	result := make([]R, len(collection))
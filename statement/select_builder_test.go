@@ -2,6 +2,7 @@ package statement_test
 
 import (
 	"database/sql"
+	"strings"
 	"testing"
 
 	"github.com/maddiesch/go-raptor/statement"
@@ -37,6 +38,77 @@ func TestSelectBuilder(t *testing.T) {
 			expectedQuery: `SELECT "FirstName", "LastName" FROM "TestTable";`,
 			expectedArgs:  nil,
 		},
+		{
+			statement:     statement.Select("p.FirstName", "pet.Name").From("People").As("p").Join("Pets").As("pet").On(conditional.EqualColumn("p.ID", "pet.ParentID")),
+			expectedQuery: `SELECT "p"."FirstName", "pet"."Name" FROM "People" AS "p" JOIN "Pets" AS "pet" ON "p"."ID" = "pet"."ParentID";`,
+			expectedArgs:  nil,
+		},
+		{
+			statement:     statement.Select("p.*").From("People").As("p").LeftJoin("Pets").As("pet").On(conditional.And(conditional.EqualColumn("p.ID", "pet.ParentID"), conditional.Equal("pet.Type", "Dog"))).Where(conditional.Equal("p.FirstName", "Maddie")),
+			expectedQuery: `SELECT "p".* FROM "People" AS "p" LEFT JOIN "Pets" AS "pet" ON ("p"."ID" = "pet"."ParentID" AND "pet"."Type" = $v1) WHERE "p"."FirstName" = $v2;`,
+			expectedArgs:  []any{sql.Named("v1", "Dog"), sql.Named("v2", "Maddie")},
+		},
+		{
+			statement:     statement.Select().From("People").Join("Accounts").Using("ID", "Email"),
+			expectedQuery: `SELECT * FROM "People" JOIN "Accounts" USING ("ID", "Email");`,
+			expectedArgs:  nil,
+		},
+		{
+			statement:     statement.Select("Type").Columns(statement.As(statement.Count("*"), "Total"), statement.Max("Age")).From("Pets").GroupBy("Type"),
+			expectedQuery: `SELECT "Type", COUNT(*) AS "Total", MAX("Age") FROM "Pets" GROUP BY "Type";`,
+			expectedArgs:  nil,
+		},
+		{
+			statement:     statement.Select().Columns(statement.Raw(`? AS "Label"`, "pets"), statement.Count("*")).From("Pets").Where(conditional.Equal("Type", "Dog")).GroupBy("ParentID").Having(statement.Raw(`COUNT(*) > ?`, 1)),
+			expectedQuery: `SELECT $v1 AS "Label", COUNT(*) FROM "Pets" WHERE "Type" = $v2 GROUP BY "ParentID" HAVING COUNT(*) > $v3;`,
+			expectedArgs:  []any{sql.Named("v1", "pets"), sql.Named("v2", "Dog"), sql.Named("v3", 1)},
+		},
+		{
+			statement:     statement.Select("FirstName").From("People").Where(conditional.And(conditional.Equal("LastName", "Woods"), conditional.InSelect("ID", statement.Select("ParentID").From("Pets").Where(conditional.Equal("Type", "Dog"))))),
+			expectedQuery: `SELECT "FirstName" FROM "People" WHERE ("LastName" = $v1 AND "ID" IN (SELECT "ParentID" FROM "Pets" WHERE "Type" = $v2));`,
+			expectedArgs:  []any{sql.Named("v1", "Woods"), sql.Named("v2", "Dog")},
+		},
+		{
+			statement:     statement.Select("p.FirstName").From("People").As("p").Where(conditional.NotExists(statement.Select("1").From("Pets").Where(conditional.And(conditional.EqualColumn("ParentID", "p.ID"), conditional.Equal("Type", "Cat"))))),
+			expectedQuery: `SELECT "p"."FirstName" FROM "People" AS "p" WHERE NOT EXISTS (SELECT "1" FROM "Pets" WHERE ("ParentID" = "p"."ID" AND "Type" = $v1));`,
+			expectedArgs:  []any{sql.Named("v1", "Cat")},
+		},
+		{
+			statement:     statement.Select("t.Name").FromSelect(statement.Select("Name").From("Pets").Where(conditional.Equal("Type", "Dog"))).As("t").Where(conditional.Equal("t.Name", "Lulu")),
+			expectedQuery: `SELECT "t"."Name" FROM (SELECT "Name" FROM "Pets" WHERE "Type" = $v1) AS "t" WHERE "t"."Name" = $v2;`,
+			expectedArgs:  []any{sql.Named("v1", "Dog"), sql.Named("v2", "Lulu")},
+		},
+		{
+			statement:     statement.Select("Name").From("Pets").Where(conditional.Equal("Type", "Dog")).Union(statement.Select("FirstName").From("People")).OrderBy("Name", true).Limit(2),
+			expectedQuery: `SELECT "Name" FROM "Pets" WHERE "Type" = $v1 UNION SELECT "FirstName" FROM "People" ORDER BY "Name" ASC LIMIT 2;`,
+			expectedArgs:  []any{sql.Named("v1", "Dog")},
+		},
+		{
+			statement:     statement.Select().Columns(statement.Raw("?", 1)),
+			expectedQuery: `SELECT $v1;`,
+			expectedArgs:  []any{sql.Named("v1", 1)},
+		},
+		{
+			statement:     statement.Select().From("People").CrossJoin("Pets"),
+			expectedQuery: `SELECT * FROM "People" CROSS JOIN "Pets";`,
+			expectedArgs:  nil,
+		},
+		{
+			statement: statement.Select("Name").From("Pets").
+				OrderByTerms(statement.OrderBy{Column: "Age", Nulls: statement.NullsLast}, statement.OrderBy{Column: "Name", Ascending: true, Collate: statement.CollationNoCase}).
+				OrderByExpr(statement.Raw(`length("Name") > ?`, 4), true),
+			expectedQuery: `SELECT "Name" FROM "Pets" ORDER BY "Age" DESC NULLS LAST, "Name" COLLATE NOCASE ASC, length("Name") > $v1 ASC;`,
+			expectedArgs:  []any{sql.Named("v1", 4)},
+		},
+		{
+			statement: statement.Select("Name").
+				Columns(statement.As(statement.OverWindow(statement.Sum("Age"), "w"), "Total")).
+				From("Pets").Where(conditional.Equal("Type", "Dog")).
+				Window("w", statement.Window().PartitionBy("ParentID").OrderBy("Name", true)).
+				OrderBy("Name", true),
+			expectedQuery: `SELECT "Name", SUM("Age") OVER "w" AS "Total" FROM "Pets" WHERE "Type" = $v1 WINDOW "w" AS (PARTITION BY "ParentID" ORDER BY "Name" ASC) ORDER BY "Name" ASC;`,
+			expectedArgs:  []any{sql.Named("v1", "Dog")},
+		},
 	}
 
 	for _, test := range tests {
@@ -49,6 +121,25 @@ func TestSelectBuilder(t *testing.T) {
 		})
 	}
 
+	t.Run("custom collation", func(t *testing.T) {
+		query, _, err := statement.Select("Name").From("Pets").OrderByTerms(statement.OrderBy{Column: "Name", Ascending: true, Collate: `NOCASE; DROP TABLE "Pets"`}).Generate()
+
+		require.NoError(t, err)
+		assert.Equal(t, `SELECT "Name" FROM "Pets" ORDER BY "Name" COLLATE "NOCASE; DROP TABLE ""Pets""" ASC;`, query)
+	})
+
+	t.Run("order by string", func(t *testing.T) {
+		assert.Equal(t, `"Name" COLLATE NOCASE ASC`, statement.OrderBy{Column: "Name", Ascending: true, Collate: statement.CollationNoCase}.String())
+		assert.True(t, strings.HasPrefix(statement.OrderBy{Expr: statement.Raw("?", 1)}.String(), "!("))
+	})
+
+	t.Run("compound select with a limit", func(t *testing.T) {
+		query, _, err := statement.Select("a").From("t").UnionAll(statement.Select("a").From("u").OrderBy("a", true).Limit(1)).Generate()
+
+		require.NoError(t, err)
+		assert.Equal(t, `SELECT "a" FROM "t" UNION ALL SELECT * FROM (SELECT "a" FROM "u" ORDER BY "a" ASC LIMIT 1);`, query)
+	})
+
 	t.Run("with a distinct limit", func(t *testing.T) {
 		query, _, err := statement.Select().Distinct().From("TestTable").Limit(1).Generate()
 
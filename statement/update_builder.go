@@ -1,8 +1,8 @@
 package statement
 
 import (
-	"database/sql"
-	"strings"
+	"errors"
+	"sort"
 
 	"github.com/maddiesch/go-raptor/statement/conditional"
 	"github.com/maddiesch/go-raptor/statement/dialect"
@@ -10,13 +10,22 @@ import (
 	"github.com/maddiesch/go-raptor/statement/query"
 )
 
+// ErrInvalidUpdate is the error of a BuildError for an update that can't be expressed in SQLite.
+var ErrInvalidUpdate = errors.New("statement: invalid update")
+
 type UpdateBuilder struct {
 	table     string
+	orAction  string
 	set       []UpdateValue
+	from      *tableRef
 	where     conditional.Conditional
-	returning []UpdateReturnValue
+	orderBy   []OrderBy
+	limit     *int64
+	returning *returningClause
 }
 
+// UpdateValue is an assignment of a SET clause, a Value that is an Expression
+// is computed by the statement instead of being bound as an argument.
 type UpdateValue struct {
 	ColumnName string
 	Value      any
@@ -33,6 +42,18 @@ func Update(t string) *UpdateBuilder {
 	}
 }
 
+// OrReplace replaces the conflicting rows when the update violates a constraint.
+func (b *UpdateBuilder) OrReplace() *UpdateBuilder {
+	b.orAction = "OR REPLACE"
+	return b
+}
+
+// OrIgnore skips the rows that would violate a constraint.
+func (b *UpdateBuilder) OrIgnore() *UpdateBuilder {
+	b.orAction = "OR IGNORE"
+	return b
+}
+
 func (b *UpdateBuilder) Set(set ...UpdateValue) *UpdateBuilder {
 	b.set = append(b.set, set...)
 	return b
@@ -42,6 +63,8 @@ func (b *UpdateBuilder) SetValue(c string, v any) *UpdateBuilder {
 	return b.Set(UpdateValue{c, v})
 }
 
+// SetMap sets the values of the map, ordered by column name so the generated
+// statement is the same for equal maps.
 func (b *UpdateBuilder) SetMap(m map[string]any) *UpdateBuilder {
 	v := make([]UpdateValue, 0, len(m))
 	for k, val := range m {
@@ -50,67 +73,132 @@ func (b *UpdateBuilder) SetMap(m map[string]any) *UpdateBuilder {
 			Value:      val,
 		})
 	}
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].ColumnName < v[j].ColumnName
+	})
 	return b.Set(v...)
 }
 
+// From adds a FROM clause, the columns of the table can be used by the values
+// and the condition of the update.
+func (b *UpdateBuilder) From(table string) *UpdateBuilder {
+	b.from = &tableRef{name: table}
+	return b
+}
+
+// FromSelect updates from a subquery named alias, e.g. to use aggregates.
+func (b *UpdateBuilder) FromSelect(sel *SelectBuilder, alias string) *UpdateBuilder {
+	b.from = &tableRef{subquery: sel, alias: alias}
+	return b
+}
+
 func (b *UpdateBuilder) Where(c conditional.Conditional) *UpdateBuilder {
 	b.where = c
 	return b
 }
 
+// OrderBy orders the rows that are updated by a Limit, it can't be used without one.
+func (b *UpdateBuilder) OrderBy(col string, asc bool) *UpdateBuilder {
+	b.orderBy = append(b.orderBy, OrderBy{
+		Column:    col,
+		Ascending: asc,
+	})
+	return b
+}
+
+// Limit limits the number of updated rows.
+//
+// SQLite only supports ORDER BY and LIMIT on an update when compiled with
+// SQLITE_ENABLE_UPDATE_DELETE_LIMIT, so the rows are selected by rowid in a
+// subquery instead. It can't be used on a WITHOUT ROWID table or with From.
+func (b *UpdateBuilder) Limit(l int64) *UpdateBuilder {
+	b.limit = &l
+	return b
+}
+
 func (b *UpdateBuilder) ReturningColumn(c ...string) *UpdateBuilder {
-	for _, col := range c {
-		b.returning = append(b.returning, UpdateReturnValue{
-			ColumnName: col,
-		})
-	}
+	b.returning = b.returning.add(returningColumns(c...)...)
 	return b
 }
 
+// Returning adds a RETURNING clause, calling it without any values returns every column.
 func (b *UpdateBuilder) Returning(c ...UpdateReturnValue) *UpdateBuilder {
-	b.returning = append(b.returning, c...)
+	b.returning = b.returning.add(c...)
 	return b
 }
 
 func (b *UpdateBuilder) Generate() (string, []any, error) {
+	q, args, err := b.GenerateFragment(generator.NewArgumentNameProvider(generator.DefaultParameterStyle))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return q + ";", args, nil
+}
+
+// GenerateFragment generates the update statement for use inside another statement.
+func (b *UpdateBuilder) GenerateFragment(provider generator.ArgumentNameProvider) (string, []any, error) {
 	var query query.Builder
 	var args []any
 
-	_, _ = query.WriteString("UPDATE " + dialect.Identifier(b.table) + " SET")
+	if b.table == "" {
+		return "", nil, missing("UPDATE", "table")
+	}
+	if len(b.set) == 0 {
+		return "", nil, missing("UPDATE", "SET values")
+	}
+	if len(b.orderBy) > 0 && b.limit == nil {
+		return "", nil, buildError("UPDATE", ErrInvalidUpdate, "ORDER BY can't be used without LIMIT")
+	}
+	if b.from != nil && b.limit != nil {
+		return "", nil, buildError("UPDATE", ErrInvalidUpdate, "LIMIT can't be used with FROM")
+	}
 
-	provider := generator.NewIncrementingArgumentNameProvider()
+	_, _ = query.WriteString("UPDATE ")
+	if b.orAction != "" {
+		_, _ = query.WriteString(b.orAction + " ")
+	}
+	_, _ = query.WriteString(dialect.Identifier(b.table) + " SET")
 
-	var values []string
-	for _, up := range b.set {
-		vName := provider.Next()
-		values = append(values, dialect.Identifier(up.ColumnName)+" = $"+vName)
-		args = append(args, sql.Named(vName, up.Value))
+	set, sArgs, err := generateSet(b.set, provider)
+	if err != nil {
+		return "", nil, err
 	}
-	if len(values) > 0 {
-		_, _ = query.WriteString(" " + strings.Join(values, ", "))
+	_, _ = query.WriteString(" " + set)
+	args = append(args, sArgs...)
+
+	if b.from != nil {
+		from, fArgs, err := b.from.generate(provider)
+		if err != nil {
+			return "", nil, err
+		}
+		_, _ = query.WriteString(" FROM " + from)
+		args = append(args, fArgs...)
 	}
 
-	if b.where != nil {
-		q, wArgs := b.where.Generate(provider)
-		_, _ = query.WriteString(" WHERE " + q)
-		args = append(args, wArgs...)
+	where := b.where
+	if b.limit != nil {
+		sel := Select("rowid").From(b.table).Where(b.where)
+		sel.orderBy = b.orderBy
+		sel.limit = b.limit
+		where = conditional.InSelect("rowid", sel)
 	}
 
-	if len(b.returning) > 0 {
-		var columns []string
-		for _, c := range b.returning {
-			var s string
-			if c.ColumnAlias != "" {
-				s = c.ColumnName + " AS " + dialect.Identifier(c.ColumnAlias)
-			} else {
-				s = dialect.Identifier(c.ColumnName)
-			}
-			columns = append(columns, s)
+	if where != nil {
+		q, wArgs, err := generateCondition("WHERE clause", where, provider)
+		if err != nil {
+			return "", nil, err
 		}
-		_, _ = query.WriteString(" RETURNING " + strings.Join(columns, ", "))
+		_, _ = query.WriteString(" WHERE " + q)
+		args = append(args, wArgs...)
 	}
 
-	return query.String(), args, nil
+	_, _ = query.WriteString(b.returning.generate())
+
+	return query.Builder.String(), args, nil
 }
 
-var _ generator.Generator = (*UpdateBuilder)(nil)
+var (
+	_ generator.Generator = (*UpdateBuilder)(nil)
+	_ generator.Fragment  = (*UpdateBuilder)(nil)
+)
@@ -8,6 +8,7 @@ import (
 	"github.com/maddiesch/go-raptor/statement/conditional"
 	"github.com/maddiesch/go-raptor/statement/generator"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUpdateBuilder(t *testing.T) {
@@ -41,6 +42,43 @@ func TestUpdateBuilder(t *testing.T) {
 			expectedQuery: `UPDATE "testing" SET "name" = $v1 WHERE "id" = $v2 RETURNING "updated_at", 1 AS "ReturnValue";`,
 			expectedArgs:  []any{sql.Named("v1", "Maddie"), sql.Named("v2", 1)},
 		},
+		{
+			statement:     statement.Update("testing").SetValue("name", "Maddie").Returning(),
+			expectedQuery: `UPDATE "testing" SET "name" = $v1 RETURNING *;`,
+			expectedArgs:  []any{sql.Named("v1", "Maddie")},
+		},
+		{
+			statement:     statement.Update("testing").SetValue("count", statement.Increment("count", 1)).SetValue("updated_at", statement.Func("unixepoch")).Where(conditional.Equal("id", 1)),
+			expectedQuery: `UPDATE "testing" SET "count" = "count" + $v1, "updated_at" = unixepoch() WHERE "id" = $v2;`,
+			expectedArgs:  []any{sql.Named("v1", 1), sql.Named("v2", 1)},
+		},
+		{
+			statement:     statement.Update("testing").OrIgnore().SetValue("name", "Maddie"),
+			expectedQuery: `UPDATE OR IGNORE "testing" SET "name" = $v1;`,
+			expectedArgs:  []any{sql.Named("v1", "Maddie")},
+		},
+		{
+			statement:     statement.Update("testing").OrIgnore().OrReplace().SetValue("name", "Maddie"),
+			expectedQuery: `UPDATE OR REPLACE "testing" SET "name" = $v1;`,
+			expectedArgs:  []any{sql.Named("v1", "Maddie")},
+		},
+		{
+			statement:     statement.Update("accounts").SetValue("balance", statement.Decrement("balance", statement.ColumnRef("t.amount"))).From("transfers").Where(conditional.EqualColumn("t.account_id", "accounts.id")),
+			expectedQuery: `UPDATE "accounts" SET "balance" = "balance" - "t"."amount" FROM "transfers" WHERE "t"."account_id" = "accounts"."id";`,
+			expectedArgs:  nil,
+		},
+		{
+			statement: statement.Update("accounts").SetValue("total", statement.ColumnRef("t.total")).
+				FromSelect(statement.Select("account_id").Columns(statement.As(statement.Sum("amount"), "total")).From("transfers").GroupBy("account_id"), "t").
+				Where(conditional.EqualColumn("t.account_id", "accounts.id")),
+			expectedQuery: `UPDATE "accounts" SET "total" = "t"."total" FROM (SELECT "account_id", SUM("amount") AS "total" FROM "transfers" GROUP BY "account_id") AS "t" WHERE "t"."account_id" = "accounts"."id";`,
+			expectedArgs:  nil,
+		},
+		{
+			statement:     statement.Update("jobs").SetValue("state", "running").Where(conditional.Equal("state", "queued")).OrderBy("created_at", true).Limit(1),
+			expectedQuery: `UPDATE "jobs" SET "state" = $v1 WHERE "rowid" IN (SELECT "rowid" FROM "jobs" WHERE "state" = $v2 ORDER BY "created_at" ASC LIMIT 1);`,
+			expectedArgs:  []any{sql.Named("v1", "running"), sql.Named("v2", "queued")},
+		},
 	}
 
 	for _, test := range tests {
@@ -53,3 +91,21 @@ func TestUpdateBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateBuilder_Invalid(t *testing.T) {
+	_, _, err := statement.Update("accounts").SetValue("balance", 0).From("transfers").Limit(1).Generate()
+
+	assert.ErrorIs(t, err, statement.ErrInvalidUpdate)
+}
+
+func TestUpdateBuilder_SetMap(t *testing.T) {
+	values := map[string]any{"e": 5, "a": 1, "d": 4, "b": 2, "c": 3}
+
+	for i := 0; i < 10; i++ {
+		query, args, err := statement.Update("testing").SetMap(values).Generate()
+
+		require.NoError(t, err)
+		assert.Equal(t, `UPDATE "testing" SET "a" = $v1, "b" = $v2, "c" = $v3, "d" = $v4, "e" = $v5;`, query)
+		assert.Equal(t, []any{sql.Named("v1", 1), sql.Named("v2", 2), sql.Named("v3", 3), sql.Named("v4", 4), sql.Named("v5", 5)}, args)
+	}
+}
@@ -0,0 +1,150 @@
+package statement
+
+import (
+	"strings"
+
+	"github.com/maddiesch/go-raptor/statement/conditional"
+	"github.com/maddiesch/go-raptor/statement/dialect"
+	"github.com/maddiesch/go-raptor/statement/generator"
+)
+
+// OnConflict adds an upsert clause for a uniqueness conflict on the columns.
+// The action must be set with DoNothing or DoUpdateSet, a clause without one
+// fails to generate.
+//
+// With no columns the clause applies to any conflict, SQLite only allows this
+// for the last clause, any other clause without columns fails to generate.
+func (b *InsertBuilder) OnConflict(columns ...string) *ConflictBuilder {
+	clause := &conflictClause{columns: columns}
+	b.conflicts = append(b.conflicts, clause)
+
+	return &ConflictBuilder{InsertBuilder: b, clause: clause}
+}
+
+// ConflictBuilder configures the action of an ON CONFLICT clause.
+//
+// It embeds the InsertBuilder the clause belongs to, so the insert can be
+// generated or further configured directly.
+type ConflictBuilder struct {
+	*InsertBuilder
+
+	clause *conflictClause
+}
+
+type conflictClause struct {
+	columns   []string
+	doNothing bool
+	set       []UpdateValue
+	where     conditional.Conditional
+}
+
+// DoNothing ignores the row when there is a conflict.
+func (c *ConflictBuilder) DoNothing() *InsertBuilder {
+	c.clause.doNothing = true
+	c.clause.set = nil
+
+	return c.InsertBuilder
+}
+
+// DoUpdateSet updates the existing row when there is a conflict.
+//
+// A value that is an Expression, such as Excluded, is used as is instead of
+// being bound as an argument.
+func (c *ConflictBuilder) DoUpdateSet(values ...UpdateValue) *ConflictBuilder {
+	c.clause.doNothing = false
+	c.clause.set = append(c.clause.set, values...)
+
+	return c
+}
+
+// DoUpdateSetExcluded updates each column of the existing row to the value
+// that failed to insert.
+func (c *ConflictBuilder) DoUpdateSetExcluded(columns ...string) *ConflictBuilder {
+	for _, col := range columns {
+		c.DoUpdateSet(UpdateValue{ColumnName: col, Value: Excluded(col)})
+	}
+
+	return c
+}
+
+// Where limits the update to existing rows that match the condition. It
+// requires DoUpdateSet, a clause that does nothing with a condition fails to
+// generate.
+func (c *ConflictBuilder) Where(condition conditional.Conditional) *ConflictBuilder {
+	c.clause.where = condition
+
+	return c
+}
+
+// Excluded references the value of the column in the row that failed to insert.
+func Excluded(column string) Expression {
+	return excludedExpression(column)
+}
+
+type excludedExpression string
+
+func (e excludedExpression) Generate(generator.ArgumentNameProvider) (string, []any) {
+	return "excluded." + dialect.Identifier(string(e)), nil
+}
+
+func (c *conflictClause) generate(provider generator.ArgumentNameProvider) (string, []any, error) {
+	var query strings.Builder
+	var args []any
+
+	_, _ = query.WriteString("ON CONFLICT")
+	if len(c.columns) > 0 {
+		columns := mapping(c.columns, func(col string) string {
+			return dialect.Identifier(col)
+		})
+		_, _ = query.WriteString(" (" + strings.Join(columns, ", ") + ")")
+	}
+
+	if c.doNothing {
+		if c.where != nil {
+			return "", nil, missing("INSERT", "DO UPDATE SET values for the ON CONFLICT WHERE clause")
+		}
+		_, _ = query.WriteString(" DO NOTHING")
+
+		return query.String(), nil, nil
+	}
+
+	if len(c.set) == 0 {
+		return "", nil, missing("INSERT", "ON CONFLICT action")
+	}
+
+	set, sArgs, err := generateSet(c.set, provider)
+	if err != nil {
+		return "", nil, err
+	}
+	_, _ = query.WriteString(" DO UPDATE SET " + set)
+	args = append(args, sArgs...)
+
+	if c.where != nil {
+		where, wArgs, err := generateCondition("WHERE clause", c.where, provider)
+		if err != nil {
+			return "", nil, err
+		}
+		_, _ = query.WriteString(" WHERE " + where)
+		args = append(args, wArgs...)
+	}
+
+	return query.String(), args, nil
+}
+
+// generateSet generates the assignments of a SET clause, a value that is an
+// Expression is used as is instead of being bound as an argument.
+func generateSet(values []UpdateValue, provider generator.ArgumentNameProvider) (string, []any, error) {
+	var args []any
+
+	set := make([]string, 0, len(values))
+	for _, up := range values {
+		value, vArgs, err := generateValue(provider, up.Value)
+		if err != nil {
+			return "", nil, err
+		}
+		set = append(set, dialect.Identifier(up.ColumnName)+" = "+value)
+		args = append(args, vArgs...)
+	}
+
+	return strings.Join(set, ", "), args, nil
+}
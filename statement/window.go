@@ -0,0 +1,157 @@
+package statement
+
+import (
+	"strings"
+
+	"github.com/maddiesch/go-raptor/statement/conditional"
+	"github.com/maddiesch/go-raptor/statement/dialect"
+	"github.com/maddiesch/go-raptor/statement/generator"
+)
+
+// Window returns a window definition, for use with Over or as a named window
+// of a select.
+func Window() *WindowBuilder {
+	return &WindowBuilder{}
+}
+
+// WindowBuilder defines the rows a window function is computed over.
+type WindowBuilder struct {
+	partitionBy []Expression
+	orderBy     []OrderBy
+	frame       string
+}
+
+// PartitionBy computes the function separately for each group of rows with
+// equal values of the columns.
+func (w *WindowBuilder) PartitionBy(columns ...string) *WindowBuilder {
+	return w.PartitionByExpr(mapping(columns, ColumnRef)...)
+}
+
+func (w *WindowBuilder) PartitionByExpr(expr ...Expression) *WindowBuilder {
+	w.partitionBy = append(w.partitionBy, expr...)
+
+	return w
+}
+
+func (w *WindowBuilder) OrderBy(col string, asc bool) *WindowBuilder {
+	return w.OrderByTerms(OrderBy{
+		Column:    col,
+		Ascending: asc,
+	})
+}
+
+func (w *WindowBuilder) OrderByTerms(o ...OrderBy) *WindowBuilder {
+	w.orderBy = append(w.orderBy, o...)
+
+	return w
+}
+
+// Frame sets the frame of the window as literal SQL, e.g. "ROWS BETWEEN 1
+// PRECEDING AND 1 FOLLOWING".
+func (w *WindowBuilder) Frame(frame string) *WindowBuilder {
+	w.frame = frame
+
+	return w
+}
+
+func (w *WindowBuilder) generate(provider generator.ArgumentNameProvider) (string, []any, error) {
+	var parts []string
+	var args []any
+
+	if len(w.partitionBy) > 0 {
+		partition := make([]string, len(w.partitionBy))
+		for i, expr := range w.partitionBy {
+			p, pArgs, err := generateExpression(expr, provider)
+			if err != nil {
+				return "", nil, err
+			}
+			partition[i] = p
+			args = append(args, pArgs...)
+		}
+		parts = append(parts, "PARTITION BY "+strings.Join(partition, ", "))
+	}
+
+	if len(w.orderBy) > 0 {
+		order, oArgs, err := generateOrderBy(w.orderBy, provider)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, "ORDER BY "+order)
+		args = append(args, oArgs...)
+	}
+
+	if w.frame != "" {
+		parts = append(parts, w.frame)
+	}
+
+	return strings.Join(parts, " "), args, nil
+}
+
+type namedWindow struct {
+	name   string
+	window *WindowBuilder
+}
+
+// Over computes the function over the window, such as a running total with
+// Sum or a ranking with RowNumber.
+func Over(fn Expression, w *WindowBuilder) Expression {
+	return &overExpression{fn: fn, window: w}
+}
+
+// OverWindow computes the function over a window named by SelectBuilder.Window.
+func OverWindow(fn Expression, name string) Expression {
+	return &overExpression{fn: fn, name: name}
+}
+
+type overExpression struct {
+	fn     Expression
+	window *WindowBuilder
+	name   string
+}
+
+func (e *overExpression) Generate(p generator.ArgumentNameProvider) (string, []any) {
+	return conditional.MustGenerate(e, p)
+}
+
+func (e *overExpression) GenerateErr(p generator.ArgumentNameProvider) (string, []any, error) {
+	fn, args, err := generateExpression(e.fn, p)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if e.window == nil {
+		return fn + " OVER " + dialect.Identifier(e.name), args, nil
+	}
+
+	window, wArgs, err := e.window.generate(p)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fn + " OVER (" + window + ")", append(args, wArgs...), nil
+}
+
+// RowNumber numbers the rows of the window partition, starting at 1.
+func RowNumber() Expression {
+	return Func("ROW_NUMBER")
+}
+
+// Rank ranks the rows of the window partition, with gaps after peers.
+func Rank() Expression {
+	return Func("RANK")
+}
+
+// DenseRank ranks the rows of the window partition, without gaps after peers.
+func DenseRank() Expression {
+	return Func("DENSE_RANK")
+}
+
+// Lag returns the value of the column in the row offset rows before the current row.
+func Lag(column string, offset int64) Expression {
+	return Func("LAG", ColumnRef(column), offset)
+}
+
+// Lead returns the value of the column in the row offset rows after the current row.
+func Lead(column string, offset int64) Expression {
+	return Func("LEAD", ColumnRef(column), offset)
+}
@@ -0,0 +1,46 @@
+package statement_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/maddiesch/go-raptor/statement"
+	"github.com/maddiesch/go-raptor/statement/generator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		expression    statement.Expression
+		expectedQuery string
+		expectedArgs  []any
+	}{
+		{statement.Over(statement.RowNumber(), statement.Window()), `ROW_NUMBER() OVER ()`, nil},
+		{
+			statement.Over(statement.Rank(), statement.Window().PartitionBy("Type", "p.ParentID").OrderByTerms(statement.OrderBy{Column: "Age", Nulls: statement.NullsLast})),
+			`RANK() OVER (PARTITION BY "Type", "p"."ParentID" ORDER BY "Age" DESC NULLS LAST)`,
+			nil,
+		},
+		{
+			statement.Over(statement.Sum("Age"), statement.Window().OrderBy("ID", true).Frame("ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW")),
+			`SUM("Age") OVER (ORDER BY "ID" ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)`,
+			nil,
+		},
+		{
+			statement.Over(statement.Lag("Age", 1), statement.Window().PartitionByExpr(statement.Raw(`"Age" > ?`, 3))),
+			`LAG("Age", $v1) OVER (PARTITION BY "Age" > $v2)`,
+			[]any{sql.Named("v1", int64(1)), sql.Named("v2", 3)},
+		},
+		{statement.OverWindow(statement.DenseRank(), "w"), `DENSE_RANK() OVER "w"`, nil},
+		{statement.OverWindow(statement.Lead("Name", 2), "w"), `LEAD("Name", $v1) OVER "w"`, []any{sql.Named("v1", int64(2))}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expectedQuery, func(t *testing.T) {
+			query, args := test.expression.Generate(generator.NewIncrementingArgumentNameProvider())
+
+			assert.Equal(t, test.expectedQuery, query)
+			assert.Equal(t, test.expectedArgs, args)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package statement
+
+import (
+	"strings"
+
+	"github.com/maddiesch/go-raptor/statement/dialect"
+	"github.com/maddiesch/go-raptor/statement/generator"
+	"github.com/maddiesch/go-raptor/statement/query"
+)
+
+// With starts a WITH clause with a common table expression named name.
+//
+// The clause prefixes the statement passed to Statement.
+func With(name string, gen generator.Fragment) *WithBuilder {
+	return (&WithBuilder{}).With(name, gen)
+}
+
+// WithRecursive starts a WITH RECURSIVE clause with a common table expression named name.
+//
+// The clause prefixes the statement passed to Statement.
+func WithRecursive(name string, gen generator.Fragment) *WithBuilder {
+	b := With(name, gen)
+	b.recursive = true
+
+	return b
+}
+
+type WithBuilder struct {
+	recursive bool
+	tables    []commonTable
+	statement generator.Fragment
+}
+
+type commonTable struct {
+	name    string
+	columns []string
+	gen     generator.Fragment
+}
+
+// With adds another common table expression to the clause.
+func (b *WithBuilder) With(name string, gen generator.Fragment) *WithBuilder {
+	b.tables = append(b.tables, commonTable{name: name, gen: gen})
+
+	return b
+}
+
+// Columns sets the column names of the most recently added common table expression.
+func (b *WithBuilder) Columns(columns ...string) *WithBuilder {
+	if len(b.tables) > 0 {
+		b.tables[len(b.tables)-1].columns = columns
+	}
+
+	return b
+}
+
+// Statement sets the statement the WITH clause prefixes, e.g. a Select, Insert,
+// Update or Delete builder. A WITH clause without a statement fails to generate.
+func (b *WithBuilder) Statement(stmt generator.Fragment) *WithBuilder {
+	b.statement = stmt
+
+	return b
+}
+
+func (b *WithBuilder) Generate() (string, []any, error) {
+	q, args, err := b.GenerateFragment(generator.NewArgumentNameProvider(generator.DefaultParameterStyle))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return q + ";", args, nil
+}
+
+// GenerateFragment generates the statement for use inside another statement.
+func (b *WithBuilder) GenerateFragment(provider generator.ArgumentNameProvider) (string, []any, error) {
+	if len(b.tables) == 0 {
+		return "", nil, missing("WITH", "common table expressions")
+	}
+	if b.statement == nil {
+		return "", nil, missing("WITH", "statement")
+	}
+
+	var query query.Builder
+	var args []any
+
+	_, _ = query.WriteString("WITH ")
+	if b.recursive {
+		_, _ = query.WriteString("RECURSIVE ")
+	}
+
+	tables := make([]string, 0, len(b.tables))
+	for _, t := range b.tables {
+		if t.name == "" || t.gen == nil {
+			return "", nil, missing("WITH", "common table expression name or statement")
+		}
+		sub, tArgs, err := t.gen.GenerateFragment(provider)
+		if err != nil {
+			return "", nil, err
+		}
+
+		table := dialect.Identifier(t.name)
+		if len(t.columns) > 0 {
+			columns := mapping(t.columns, func(c string) string {
+				return dialect.Identifier(c)
+			})
+			table += " (" + strings.Join(columns, ", ") + ")"
+		}
+		tables = append(tables, table+" AS ("+sub+")")
+		args = append(args, tArgs...)
+	}
+	_, _ = query.WriteString(strings.Join(tables, ", "))
+
+	stmt, sArgs, err := b.statement.GenerateFragment(provider)
+	if err != nil {
+		return "", nil, err
+	}
+	_, _ = query.WriteString(" " + stmt)
+	args = append(args, sArgs...)
+
+	return query.Builder.String(), args, nil
+}
+
+var (
+	_ generator.Generator = (*WithBuilder)(nil)
+	_ generator.Fragment  = (*WithBuilder)(nil)
+)
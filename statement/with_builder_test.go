@@ -0,0 +1,62 @@
+package statement_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/maddiesch/go-raptor/statement"
+	"github.com/maddiesch/go-raptor/statement/conditional"
+	"github.com/maddiesch/go-raptor/statement/generator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithBuilder(t *testing.T) {
+	tests := []struct {
+		statement     generator.Generator
+		expectedQuery string
+		expectedArgs  []any
+	}{
+		{
+			statement: statement.With("Dogs", statement.Select().From("Pets").Where(conditional.Equal("Type", "Dog"))).
+				Statement(statement.Select("Name").From("Dogs").Where(conditional.Equal("Age", 5))),
+			expectedQuery: `WITH "Dogs" AS (SELECT * FROM "Pets" WHERE "Type" = $v1) SELECT "Name" FROM "Dogs" WHERE "Age" = $v2;`,
+			expectedArgs:  []any{sql.Named("v1", "Dog"), sql.Named("v2", 5)},
+		},
+		{
+			statement: statement.WithRecursive("Counter", statement.Select().Columns(statement.Raw("1")).UnionAll(
+				statement.Select().Columns(statement.Raw(`"x" + 1`)).From("Counter").Where(conditional.LessThan("x", 10)),
+			)).Columns("x").Statement(statement.Select("x").From("Counter")),
+			expectedQuery: `WITH RECURSIVE "Counter" ("x") AS (SELECT 1 UNION ALL SELECT "x" + 1 FROM "Counter" WHERE "x" < $v1) SELECT "x" FROM "Counter";`,
+			expectedArgs:  []any{sql.Named("v1", 10)},
+		},
+		{
+			statement: statement.With("Old", statement.Select("ID").From("People").Where(conditional.Equal("Archived", true))).
+				With("Young", statement.Select("ID").From("People").Where(conditional.Equal("Archived", false))).
+				Statement(statement.Delete().From("People").Where(conditional.InSelect("ID", statement.Select("ID").From("Old")))),
+			expectedQuery: `WITH "Old" AS (SELECT "ID" FROM "People" WHERE "Archived" = $v1), "Young" AS (SELECT "ID" FROM "People" WHERE "Archived" = $v2) DELETE FROM "People" WHERE "ID" IN (SELECT "ID" FROM "Old");`,
+			expectedArgs:  []any{sql.Named("v1", true), sql.Named("v2", false)},
+		},
+		{
+			statement: statement.With("Names", statement.Select("Name").From("Pets")).
+				Statement(statement.Update("People").SetValue("FirstName", "Sterling").Where(conditional.InSelect("FirstName", statement.Select("Name").From("Names")))),
+			expectedQuery: `WITH "Names" AS (SELECT "Name" FROM "Pets") UPDATE "People" SET "FirstName" = $v1 WHERE "FirstName" IN (SELECT "Name" FROM "Names");`,
+			expectedArgs:  []any{sql.Named("v1", "Sterling")},
+		},
+		{
+			statement: statement.With("Names", statement.Select("Name").From("Pets").Where(conditional.Equal("Type", "Dog"))).
+				Statement(statement.Insert().Into("Log").Value("Message", "dogs")),
+			expectedQuery: `WITH "Names" AS (SELECT "Name" FROM "Pets" WHERE "Type" = $v1) INSERT INTO "Log" ("Message") VALUES ($v2);`,
+			expectedArgs:  []any{sql.Named("v1", "Dog"), sql.Named("v2", "dogs")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expectedQuery, func(t *testing.T) {
+			query, args, err := test.statement.Generate()
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.expectedQuery, query)
+				assert.Equal(t, test.expectedArgs, args)
+			}
+		})
+	}
+}
@@ -2,13 +2,17 @@ package raptor_test
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/maddiesch/go-raptor"
 	"github.com/maddiesch/go-raptor/internal/test"
 	"github.com/maddiesch/go-raptor/raptortest"
 	"github.com/maddiesch/go-raptor/statement"
 	"github.com/maddiesch/go-raptor/statement/conditional"
+	"github.com/maddiesch/go-raptor/statement/generator"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -27,6 +31,222 @@ func TestConn_QueryRowStatement(t *testing.T) {
 	assert.Equal(t, "Schipper", lastName)
 }
 
+func TestConn_QueryStatement_Join(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	query := statement.Select("p.FirstName", "pet.Name").From("People").As("p").
+		Join("Pets").As("pet").On(conditional.EqualColumn("p.ID", "pet.ParentID")).
+		Where(conditional.Equal("p.FirstName", "Elle"))
+
+	var firstName, petName string
+	err := conn.QueryRowStatement(ctx, query).Scan(&firstName, &petName)
+
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Elle", firstName)
+	assert.Equal(t, "Bruiser", petName)
+}
+
+func TestConn_QueryStatement_GroupBy(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	query := statement.Select("Type").Columns(statement.As(statement.Count("*"), "Total")).From("Pets").
+		GroupBy("Type").
+		Having(conditional.GreaterThan("Total", 1))
+
+	var kind string
+	var total int64
+	err := conn.QueryRowStatement(ctx, query).Scan(&kind, &total)
+
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Dog", kind)
+	assert.Equal(t, int64(3), total)
+}
+
+func TestConn_QueryStatement_In(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	for _, cond := range []conditional.Conditional{
+		conditional.In("Name", []string{"Lulu", "Bruiser"}),
+		conditional.InJSON("Name", []string{"Lulu", "Bruiser"}),
+	} {
+		rows, err := conn.QueryStatement(ctx, statement.Select("Name").From("Pets").Where(cond).OrderBy("Name", true))
+		require.NoError(t, err)
+
+		records, err := raptor.ScanAllRecord(rows)
+		require.NoError(t, err)
+
+		if assert.Len(t, records, 2) {
+			assert.Equal(t, "Bruiser", records[0].GetString("Name"))
+			assert.Equal(t, "Lulu", records[1].GetString("Name"))
+		}
+	}
+
+	var count int64
+	err := conn.QueryRowStatement(ctx, statement.Select().Columns(statement.Count("*")).From("Pets").Where(conditional.In("Name", []string{}))).Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestConn_QueryStatement_WithRecursive(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	query := statement.WithRecursive("Counter", statement.Select().Columns(statement.Raw("1")).UnionAll(
+		statement.Select().Columns(statement.Raw(`"x" + 1`)).From("Counter").Where(conditional.LessThan("x", 5)),
+	)).Columns("x").Statement(statement.Select().Columns(statement.Sum("x")).From("Counter"))
+
+	var sum int64
+	err := conn.QueryRowStatement(ctx, query).Scan(&sum)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(15), sum)
+}
+
+func TestConn_QueryStatement_Operators(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	tests := map[string]conditional.Conditional{
+		"regexp":           conditional.Regexp("Name", `^B.*r$`),
+		"glob":             conditional.Glob("Name", "Br*"),
+		"between":          conditional.AndAll(conditional.Between("Name", "B", "C"), nil),
+		"is distinct from": conditional.AndAll(conditional.IsDistinctFrom("Name", "Sterling"), conditional.IsDistinctFrom("Name", "Lulu")),
+		"not":              conditional.Not(conditional.In("Name", []string{"Sterling", "Lulu"})),
+	}
+
+	for name, where := range tests {
+		t.Run(name, func(t *testing.T) {
+			var petName string
+			err := conn.QueryRowStatement(ctx, statement.Select("Name").From("Pets").Where(where)).Scan(&petName)
+
+			require.NoError(t, err)
+			assert.Equal(t, "Bruiser", petName)
+		})
+	}
+
+	t.Run("invalid regexp", func(t *testing.T) {
+		var petName string
+		err := conn.QueryRowStatement(ctx, statement.Select("Name").From("Pets").Where(conditional.Regexp("Name", `(`))).Scan(&petName)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestConn_QueryStatement_Like(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	count := func(t *testing.T, where conditional.Conditional) int {
+		var n int
+		err := conn.QueryRowStatement(ctx, statement.Select().Columns(statement.Count("*")).From("Pets").Where(where)).Scan(&n)
+		require.NoError(t, err)
+		return n
+	}
+
+	assert.Equal(t, 1, count(t, conditional.StringContains("Name", "ruis")))
+	assert.Equal(t, 0, count(t, conditional.StringHasPrefix("Name", "B_")))
+	assert.Equal(t, 0, count(t, conditional.StringHasSuffix("Name", "%")))
+	assert.Equal(t, 2, count(t, conditional.NotLike("Name", "B%")))
+
+	_, err := conn.Exec(ctx, "PRAGMA case_sensitive_like = ON")
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, count(t, conditional.StringContains("Name", "RUIS")))
+	assert.Equal(t, 1, count(t, conditional.CaseInsensitive(conditional.StringContains("Name", "RUIS"))))
+}
+
+func TestConn_QueryStatement_Window(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	query := statement.Select("Name").
+		Columns(
+			statement.As(statement.Over(statement.RowNumber(), statement.Window().PartitionBy("Type").OrderByTerms(statement.OrderBy{Column: "Age", Nulls: statement.NullsLast}, statement.OrderBy{Column: "Name", Ascending: true})), "Position"),
+			statement.As(statement.OverWindow(statement.Count("*"), "all"), "Total"),
+		).
+		From("Pets").
+		Window("all", statement.Window().PartitionBy("Type")).
+		OrderByTerms(statement.OrderBy{Column: "Age", Ascending: true, Nulls: statement.NullsLast}, statement.OrderBy{Column: "Name", Ascending: false, Collate: statement.CollationNoCase})
+
+	rows, err := conn.QueryStatement(ctx, query)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var names []string
+	var positions []int64
+	for rows.Next() {
+		var name string
+		var position, total int64
+		require.NoError(t, rows.Scan(&name, &position, &total))
+		assert.Equal(t, int64(3), total)
+		names = append(names, name)
+		positions = append(positions, position)
+	}
+	require.NoError(t, rows.Err())
+
+	assert.Equal(t, []string{"Sterling", "Lulu", "Bruiser"}, names)
+	assert.Equal(t, []int64{1, 3, 2}, positions)
+}
+
+func TestConn_QueryStatement_ParameterStyle(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	query := statement.Select("p.FirstName").From("People").As("p").
+		Where(conditional.And(
+			conditional.In("p.LastName", []string{"Woods", "Briggs"}),
+			conditional.ExistsSelect(statement.Select("ID").From("Pets").Where(conditional.And(
+				conditional.EqualColumn("Pets.ParentID", "p.ID"),
+				conditional.Equal("Name", "Bruiser"),
+			))),
+		))
+
+	for _, style := range []generator.ParameterStyle{generator.ParameterStyleNamed, generator.ParameterStylePositional, generator.ParameterStyleNumbered} {
+		var firstName string
+		err := conn.QueryRowStatement(ctx, generator.WithParameterStyle(query, style)).Scan(&firstName)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Elle", firstName)
+	}
+
+	q, args, err := query.Generate()
+	require.NoError(t, err)
+
+	literal, err := generator.Interpolate(q, args)
+	require.NoError(t, err)
+
+	var firstName string
+	err = conn.QueryRow(ctx, literal).Scan(&firstName)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Elle", firstName)
+}
+
+func TestLiteral_Time(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	now := time.Now()
+	literal, err := generator.Literal(now)
+	require.NoError(t, err)
+
+	var equal bool
+	err = conn.QueryRow(ctx, "SELECT "+literal+" = ?;", now).Scan(&equal)
+
+	require.NoError(t, err)
+	assert.True(t, equal)
+}
+
+func TestConn_QueryStatement_CompoundLimit(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	query := statement.Select().Columns(statement.Count("*")).FromSelect(
+		statement.Select("Name").From("Pets").UnionAll(statement.Select("FirstName").From("People").OrderBy("FirstName", true).Limit(1)),
+	).As("n")
+
+	var count int
+	err := conn.QueryRowStatement(ctx, query).Scan(&count)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, count, "the limit should only apply to the people")
+}
+
 func TestConn_QueryStatement(t *testing.T) {
 	conn, ctx := test.Setup(t)
 
@@ -104,6 +324,278 @@ func TestExecStatement(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestBulkInsert(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	defer func(n int) { statement.MaxVariableNumber = n }(statement.MaxVariableNumber)
+	statement.MaxVariableNumber = 10
+
+	people := make([]raptor.Record, 12)
+	for i := range people {
+		people[i] = raptor.Record{"FirstName": fmt.Sprintf("Taylor %d", i), "LastName": "Swift"}
+	}
+
+	count, err := raptor.BulkInsert(ctx, conn, statement.Insert().Into("People").Rows(people))
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), count)
+
+	var total int64
+	err = conn.QueryRowStatement(ctx, statement.Select().Columns(statement.Count("*")).From("People").Where(conditional.Equal("LastName", "Swift"))).Scan(&total)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), total)
+}
+
+func TestBulkInsert_Rollback(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	defer func(n int) { statement.MaxVariableNumber = n }(statement.MaxVariableNumber)
+	statement.MaxVariableNumber = 2
+
+	people := []raptor.Record{
+		{"FirstName": "Taylor", "LastName": "Swift"},
+		{"FirstName": "Taylor", "LastName": "Swift"},
+	}
+
+	_, err := raptor.BulkInsert(ctx, conn, statement.Insert().Into("People").Rows(people))
+
+	require.Error(t, err)
+
+	var total int64
+	err = conn.QueryRowStatement(ctx, statement.Select().Columns(statement.Count("*")).From("People").Where(conditional.Equal("FirstName", "Taylor"))).Scan(&total)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+}
+
+func TestBulkInsert_NoRows(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	count, err := raptor.BulkInsert(ctx, conn, statement.Insert().Into("People").Rows([]raptor.Record{}))
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	var total int64
+	err = conn.QueryRowStatement(ctx, statement.Select().Columns(statement.Count("*")).From("People")).Scan(&total)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+}
+
+func TestConn_ExecStatement_UpdateComputed(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	update := statement.Update("Pets").
+		SetValue("Age", statement.Increment("Age", 1)).
+		Where(conditional.IsNot("Age", nil)).
+		ReturningColumn("Age")
+
+	var age int64
+	err := conn.QueryRowStatement(ctx, update).Scan(&age)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), age)
+
+	t.Run("order by and limit", func(t *testing.T) {
+		update := statement.Update("Pets").SetValue("Age", 1).Where(conditional.Equal("Type", "Dog")).OrderBy("Name", true).Limit(2)
+
+		_, err := conn.ExecStatement(ctx, update)
+		require.NoError(t, err)
+
+		var names []string
+		rows, err := conn.QueryStatement(ctx, statement.Select("Name").From("Pets").Where(conditional.Equal("Age", 1)).OrderBy("Name", true))
+		require.NoError(t, err)
+		defer rows.Close()
+		for rows.Next() {
+			var name string
+			require.NoError(t, rows.Scan(&name))
+			names = append(names, name)
+		}
+		assert.Equal(t, []string{"Bruiser", "Lulu"}, names)
+	})
+
+	t.Run("from", func(t *testing.T) {
+		update := statement.Update("Pets").
+			SetValue("Name", statement.Expr(`"p"."FirstName" || ' ' || "Pets"."Name"`)).
+			FromSelect(statement.Select("ID", "FirstName").From("People"), "p").
+			Where(conditional.EqualColumn("p.ID", "Pets.ParentID"))
+
+		_, err := conn.ExecStatement(ctx, update)
+		require.NoError(t, err)
+
+		var name string
+		err = conn.QueryRowStatement(ctx, statement.Select("Name").From("Pets").Where(conditional.Equal("ParentID", 2))).Scan(&name)
+		require.NoError(t, err)
+		assert.Equal(t, "Elle Bruiser", name)
+	})
+}
+
+func TestConn_ExecStatement_Archive(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	_, err := conn.Exec(ctx, `CREATE TABLE "Archive" ("FirstName" TEXT NOT NULL UNIQUE, "LastName" TEXT NOT NULL);`)
+	require.NoError(t, err)
+
+	archive := statement.Insert().Into("Archive").
+		FromSelect([]string{"FirstName", "LastName"}, statement.Select("FirstName", "LastName").From("People")).
+		OnConflict().DoNothing()
+
+	result, err := conn.ExecStatement(ctx, archive)
+	require.NoError(t, err)
+
+	archived, err := result.RowsAffected()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), archived)
+
+	rows, err := conn.QueryStatement(ctx, statement.Delete().From("People").Where(conditional.Equal("FirstName", "Elle")).Returning("LastName"))
+	require.NoError(t, err)
+	defer rows.Close()
+
+	require.True(t, rows.Next())
+
+	var lastName string
+	require.NoError(t, rows.Scan(&lastName))
+	assert.Equal(t, "Woods", lastName)
+
+	assert.False(t, rows.Next())
+}
+
+func TestConn_ExecStatement_CreateTableConstraints(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	_, err := conn.Exec(ctx, "PRAGMA foreign_keys = ON;")
+	require.NoError(t, err)
+
+	create := statement.CreateTable("Tags").
+		Column(
+			statement.Column("PetID", statement.ColumnTypeInteger).NotNull().References("Pets", "ID").OnDelete(statement.Cascade),
+			statement.Column("Name", statement.ColumnTypeText).NotNull().Collate(statement.CollationNoCase),
+		).
+		PrimaryKeyColumns("PetID", "Name").
+		Check(conditional.NotEqual("Name", ""))
+
+	_, err = conn.ExecStatement(ctx, create)
+	require.NoError(t, err)
+
+	_, err = conn.Exec(ctx, `INSERT INTO "Tags" ("PetID", "Name") SELECT "ID", 'good' FROM "Pets" WHERE "Name" = 'Sterling';`)
+	require.NoError(t, err)
+
+	_, err = conn.Exec(ctx, `INSERT INTO "Tags" ("PetID", "Name") SELECT "ID", 'GOOD' FROM "Pets" WHERE "Name" = 'Sterling';`)
+	assert.Error(t, err, "the primary key should use the column collation")
+
+	_, err = conn.Exec(ctx, `INSERT INTO "Tags" ("PetID", "Name") SELECT "ID", '' FROM "Pets" WHERE "Name" = 'Lulu';`)
+	assert.Error(t, err, "the check constraint should fail")
+
+	_, err = conn.ExecStatement(ctx, statement.Delete().From("Pets").Where(conditional.Equal("Name", "Sterling")))
+	require.NoError(t, err)
+
+	var count int
+	err = conn.QueryRow(ctx, `SELECT COUNT(*) FROM "Tags";`).Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestConn_ExecStatement_CreateStrictTable(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	create := statement.CreateTable("Scores").Strict().WithoutRowID().Column(
+		statement.Column("Name", statement.ColumnTypeText).PrimaryKey(),
+		statement.Column("Points", statement.ColumnTypeInteger).NotNull(),
+		statement.Column("Double", statement.ColumnTypeInteger).GeneratedAs(statement.Raw(`"Points" * ?`, 2), false),
+	)
+
+	_, err := conn.ExecStatement(ctx, create)
+	require.NoError(t, err)
+
+	_, err = conn.ExecStatement(ctx, statement.Insert().Into("Scores").Value("Name", "Maddie").Value("Points", "many"))
+	assert.Error(t, err, "a STRICT table should reject a value of the wrong type")
+
+	_, err = conn.ExecStatement(ctx, statement.Insert().Into("Scores").Value("Name", "Maddie").Value("Points", 21))
+	require.NoError(t, err)
+
+	var double int
+	err = conn.QueryRowStatement(ctx, statement.Select("Double").From("Scores")).Scan(&double)
+	require.NoError(t, err)
+	assert.Equal(t, 42, double)
+}
+
+func TestConn_ExecStatement_CreateTableFor(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	type book struct {
+		ID     int64   `db:",pk"`
+		Title  string  `db:"Title,unique"`
+		Author string  `db:"Author,index"`
+		Pages  *int64  `db:"PageCount"`
+		Rating float64 `db:",default=2.5"`
+	}
+
+	err := raptor.ExecStatements(ctx, conn, statement.CreateTableFor[book]("Books").Statements()...)
+	require.NoError(t, err)
+
+	var indexes int
+	err = conn.QueryRow(ctx, `SELECT COUNT(*) FROM sqlite_schema WHERE type = 'index' AND name = 'Books_Author_index'`).Scan(&indexes)
+	require.NoError(t, err)
+	assert.Equal(t, 1, indexes)
+
+	_, err = conn.ExecStatement(ctx, statement.Insert().Into("Books").Objects([]book{
+		{ID: 1, Title: "Legally Blonde", Author: "Amanda Brown", Rating: 4},
+	}))
+	require.NoError(t, err)
+
+	var b book
+	err = raptor.UnmarshalRow(conn.QueryRowStatement(ctx, statement.Select("*").From("Books")), &b)
+	require.NoError(t, err)
+
+	assert.Equal(t, book{ID: 1, Title: "Legally Blonde", Author: "Amanda Brown", Rating: 4}, b)
+}
+
+func TestConn_ExecStatement_CreateTableForKeys(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	type counter struct {
+		ID    *int64 `db:",pk"`
+		Count uint64
+	}
+
+	_, err := conn.ExecStatement(ctx, statement.CreateTableFor[counter]("Counters"))
+	require.NoError(t, err)
+
+	_, err = conn.ExecStatement(ctx, statement.Insert().Into("Counters").Objects([]counter{{Count: 1}, {Count: 2}}))
+	require.NoError(t, err)
+
+	var ids []int64
+	rows, err := conn.Query(ctx, `SELECT "ID" FROM "Counters" ORDER BY "ID"`)
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		require.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []int64{1, 2}, ids, "SQLite should assign the keys of nil fields")
+
+	_, err = conn.ExecStatement(ctx, statement.Insert().Into("Counters").Objects([]counter{{Count: math.MaxUint64}}))
+	assert.Error(t, err, "a value above the INTEGER range should fail to insert")
+}
+
+func TestExecStatements_Rollback(t *testing.T) {
+	conn, ctx := test.Setup(t)
+
+	alter := statement.AlterTable("People").AddColumn(statement.Column("Age", statement.ColumnTypeInteger)).DropColumn("Unknown")
+
+	err := raptor.ExecStatements(ctx, conn, alter.Statements()...)
+	require.Error(t, err)
+
+	var columns int
+	err = conn.QueryRow(ctx, `SELECT COUNT(*) FROM pragma_table_info('People') WHERE name = 'Age'`).Scan(&columns)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, columns, "the first change should be rolled back")
+}
+
 func TestQueryStatementInsert(t *testing.T) {
 	conn, ctx := test.Setup(t)
 
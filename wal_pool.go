@@ -0,0 +1,132 @@
+package raptor
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/maddiesch/go-raptor/pool"
+)
+
+// WALPoolOption configures a pool created by NewWALPool.
+type WALPoolOption func(*walPoolConfig)
+
+type walPoolConfig struct {
+	busyTimeout time.Duration
+	pragmas     []string
+	logger      QueryLogger
+}
+
+// WithBusyTimeout sets how long a connection waits on a locked database before
+// returning SQLITE_BUSY. Defaults to 5 seconds.
+func WithBusyTimeout(d time.Duration) WALPoolOption {
+	return func(c *walPoolConfig) {
+		c.busyTimeout = d
+	}
+}
+
+// WithPragma adds a pragma that is applied to every connection in the pool.
+// e.g. WithPragma("foreign_keys(1)")
+func WithPragma(pragma string) WALPoolOption {
+	return func(c *walPoolConfig) {
+		c.pragmas = append(c.pragmas, pragma)
+	}
+}
+
+// WithPoolQueryLogger assigns the query logger used by every connection in the pool.
+func WithPoolQueryLogger(l QueryLogger) WALPoolOption {
+	return func(c *walPoolConfig) {
+		c.logger = l
+	}
+}
+
+// NewWALPool opens a pool for the database file at path using SQLite's
+// write-ahead log.
+//
+// The pool holds a single read-write connection that is used by Exec, Transact
+// and ForWriting, and up to readers read-only connections used by Query,
+// QueryRow and Reader. Because the database is in WAL mode, readers are not
+// blocked by the writer. A query made while every reader has open rows waits
+// for one of them to be closed.
+//
+// Panic if readers is less than 1.
+func NewWALPool(path string, readers int, opts ...WALPoolOption) (*Pool, error) {
+	if readers < 1 {
+		panic("raptor: pool readers must be at least 1")
+	}
+
+	config := walPoolConfig{
+		busyTimeout: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	// The writer is opened eagerly so the database file exists, and is in WAL
+	// mode, before any read-only connection attempts to open it.
+	writer, err := openWALConn(config.source(path, false), config.logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := writer.Ping(context.Background()); err != nil {
+		_ = writer.Close()
+		return nil, err
+	}
+
+	// With a max size of 1 the builder hands out the same writer connection
+	// every time the pool needs a value. It's loaded into the pool right away,
+	// so closing the pool closes the writer even if it was never used.
+	writePool := pool.New[*Conn](pool.Config{MaxSize: 1}, func(context.Context) (*Conn, error) {
+		return writer, nil
+	})
+	if err := pool.Load(context.Background(), writePool, 1); err != nil {
+		_ = writer.Close()
+		return nil, err
+	}
+
+	readSource := config.source(path, true)
+
+	return &Pool{
+		Pool: pool.New[*Conn](pool.Config{MaxSize: int64(readers)}, func(context.Context) (*Conn, error) {
+			return openWALConn(readSource, config.logger)
+		}),
+		writer: writePool,
+	}, nil
+}
+
+func (c walPoolConfig) source(path string, readOnly bool) string {
+	query := url.Values{}
+
+	query.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", c.busyTimeout.Milliseconds()))
+	if readOnly {
+		query.Set("mode", "ro")
+		query.Add("_pragma", "query_only(1)")
+	} else {
+		query.Add("_pragma", "journal_mode(WAL)")
+	}
+	for _, p := range c.pragmas {
+		query.Add("_pragma", p)
+	}
+
+	return "file:" + path + "?" + query.Encode()
+}
+
+// openWALConn opens a connection to the pool's database.
+//
+// Each connection is backed by exactly one SQLite connection, so savepoints
+// always apply to the same handle and the pool limits the number of SQLite
+// connections.
+func openWALConn(source string, logger QueryLogger) (*Conn, error) {
+	conn, err := New(source)
+	if err != nil {
+		return nil, err
+	}
+	conn.db.SetMaxOpenConns(1)
+
+	if logger != nil {
+		conn.SetQueryLogger(logger)
+	}
+
+	return conn, nil
+}